@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"exif/pkg/exif"
+)
+
+// runDump prints every metadata segment and IFD entry of a file.
+func runDump(args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	pretty := fs.Bool("pretty", false, "print values with their print conversion, e.g. \"f/5.6\" instead of 56/10")
+	asJSON := fs.Bool("json", false, "print the metadata as JSON")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expect exactly one file")
+	}
+	path := fs.Arg(0)
+
+	meta, err := exif.DecodeFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to decode exif: %w", err)
+	}
+
+	if *asJSON {
+		return printJSON(meta, *pretty)
+	}
+
+	if meta.JFIF != nil {
+		fmt.Printf("JFIF %d.%02d density=%dx%d units=%d thumbnail=%dx%d\n",
+			meta.JFIF.MajorVersion, meta.JFIF.MinorVersion, meta.JFIF.XDensity, meta.JFIF.YDensity,
+			meta.JFIF.Units, meta.JFIF.XThumbnail, meta.JFIF.YThumbnail)
+	}
+	if meta.JFXX != nil {
+		fmt.Printf("JFXX extension=0x%02X thumbnail=%d bytes\n", byte(meta.JFXX.ExtensionCode), len(meta.JFXX.ThumbnailData))
+	}
+
+	if meta.IFD0 == nil {
+		return nil
+	}
+
+	fmt.Println("endianness:", meta.ByteOrder.String())
+	printIFD("IFD0", meta.IFD0, *pretty)
+	printIFD("Exif", meta.Exif, *pretty)
+	printIFD("Interop", meta.Interop, *pretty)
+	printIFD("GPS", meta.GPS, *pretty)
+	if g, err := meta.GPSInfo(); err == nil {
+		fmt.Printf("  position: %.6f, %.6f", g.Latitude, g.Longitude)
+		if g.Altitude != nil {
+			fmt.Printf(" altitude %.1f m", *g.Altitude)
+		}
+		if !g.Time.IsZero() {
+			fmt.Printf(" at %s", g.Time.Format(time.RFC3339))
+		}
+		fmt.Println()
+	}
+	for i, ifd := range meta.Chain[1:] {
+		printIFD(fmt.Sprintf("IFD%d", i+1), ifd, *pretty)
+	}
+	for _, w := range meta.Warnings {
+		fmt.Printf("\nWARNING: %v\n", w)
+	}
+	return nil
+}
+
+func printIFD(title string, ifd *exif.IFD, pretty bool) {
+	if ifd == nil {
+		return
+	}
+
+	fmt.Printf("\n%s IFD entries (offset %d):\n", title, ifd.Offset)
+	for _, e := range ifd.Entries {
+		var value any = e.Value
+		if pretty {
+			value = ifd.Format(e)
+		}
+		fmt.Printf("  %-24s Tag=0x%04X Type=%d Count=%d Value=%v\n",
+			e.Name, e.TagID, e.TypeID, e.Count, value)
+	}
+
+	for _, id := range ifd.Duplicates() {
+		fmt.Printf("  WARNING: tag 0x%04X is duplicated\n", id)
+	}
+}
+
+// printJSON prints meta as JSON. In pretty mode every IFD is an object of
+// tag names to print-converted values.
+func printJSON(meta *exif.Metadata, pretty bool) error {
+	var v any = meta
+	if pretty {
+		ifds := map[string]map[string]string{}
+		for name, ifd := range map[string]*exif.IFD{"ifd0": meta.IFD0, "exif": meta.Exif, "gps": meta.GPS, "interop": meta.Interop, "ifd1": meta.IFD1} {
+			if ifd == nil {
+				continue
+			}
+			ifds[name] = make(map[string]string, len(ifd.Entries))
+			for _, e := range ifd.Entries {
+				ifds[name][e.Name] = ifd.Format(e)
+			}
+		}
+		v = ifds
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"exif/pkg/exif"
+	"exif/pkg/tag"
+)
+
+// photo is the position of a picture, with the properties exported.
+type photo struct {
+	path   string
+	gps    *exif.GPS
+	time   string // capture time, RFC 3339 without offset when unknown
+	camera string // Make and Model
+}
+
+// runExportGeo writes the positions of JPEG files as GeoJSON, KML or GPX.
+func runExportGeo(args []string) error {
+	fs := flag.NewFlagSet("export-geo", flag.ExitOnError)
+	out := fs.String("o", "", "output file (default: standard output)")
+	format := fs.String("format", "", "geojson, kml or gpx (default: after the extension of -o, or geojson)")
+	tz := fs.String("tz", "", `time zone of capture times without offset, e.g. "Europe/Paris" or "+02:00" (default: none)`)
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("expect at least one file or directory")
+	}
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*out)) {
+		case ".kml":
+			*format = "kml"
+		case ".gpx":
+			*format = "gpx"
+		default:
+			*format = "geojson"
+		}
+	}
+	write, ok := map[string]func(io.Writer, []photo) error{
+		"geojson": writeGeoJSON,
+		"kml":     writeKML,
+		"gpx":     writeGPX,
+	}[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	var loc *time.Location
+	if *tz != "" {
+		var err error
+		if loc, err = parseZone(*tz); err != nil {
+			return err
+		}
+	}
+
+	paths, err := jpegFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+	var photos []photo
+	for _, path := range paths {
+		p, err := readPhoto(path, loc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			continue
+		}
+		photos = append(photos, *p)
+	}
+
+	if *out == "" {
+		if err := write(os.Stdout, photos); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		if err := write(f, photos); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d files have a position\n", len(photos), len(paths))
+	return nil
+}
+
+// readPhoto returns the position and properties of the file at path.
+// Capture times without offset are in loc, or have no offset when nil;
+// the GPS time is used when there is no DateTimeOriginal.
+func readPhoto(path string, loc *time.Location) (*photo, error) {
+	meta, err := exif.DecodeFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exif: %w", err)
+	}
+	g, err := meta.GPSInfo()
+	if err != nil {
+		return nil, err
+	}
+	p := &photo{path: path, gps: g}
+
+	_, hasOffset := meta.Get(tag.OffsetTimeOriginal)
+	if t, err := meta.DateTimeOriginal(loc); err == nil {
+		if hasOffset || loc != nil {
+			p.time = t.Format(time.RFC3339)
+		} else {
+			p.time = t.Format("2006-01-02T15:04:05")
+		}
+	} else if !g.Time.IsZero() {
+		p.time = g.Time.UTC().Format(time.RFC3339)
+	}
+
+	ascii := func(t tag.Tag) string {
+		e, _ := meta.Get(t)
+		s, _ := e.Value.(string)
+		return strings.TrimSpace(s)
+	}
+	maker, model := ascii(tag.Make), ascii(tag.Model)
+	switch {
+	case maker == "" || strings.HasPrefix(strings.ToLower(model), strings.ToLower(maker)):
+		p.camera = model
+	case model == "":
+		p.camera = maker
+	default:
+		p.camera = maker + " " + model
+	}
+	return p, nil
+}
+
+// name returns the file name of p.
+func (p *photo) name() string {
+	return filepath.Base(p.path)
+}
+
+// description returns the camera and direction of p, e.g.
+// "NIKON COOLPIX P6000, direction 135.0° T".
+func (p *photo) description() string {
+	var parts []string
+	if p.camera != "" {
+		parts = append(parts, p.camera)
+	}
+	if p.gps.ImgDirection != nil {
+		parts = append(parts, fmt.Sprintf("direction %.1f° %s", *p.gps.ImgDirection, p.gps.ImgDirectionRef))
+	}
+	return strings.TrimSpace(strings.Join(parts, ", "))
+}
+
+func writeGeoJSON(w io.Writer, photos []photo) error {
+	type feature struct {
+		Type     string `json:"type"`
+		Geometry struct {
+			Type        string    `json:"type"`
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+	fc := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: []feature{}}
+
+	for _, p := range photos {
+		f := feature{Type: "Feature", Properties: map[string]any{
+			"filename": p.name(),
+			"path":     p.path,
+		}}
+		f.Geometry.Type = "Point"
+		f.Geometry.Coordinates = []float64{p.gps.Longitude, p.gps.Latitude}
+		if p.gps.Altitude != nil {
+			f.Geometry.Coordinates = append(f.Geometry.Coordinates, *p.gps.Altitude)
+		}
+		if p.time != "" {
+			f.Properties["time"] = p.time
+		}
+		if p.camera != "" {
+			f.Properties["camera"] = p.camera
+		}
+		if p.gps.ImgDirection != nil {
+			f.Properties["direction"] = *p.gps.ImgDirection
+			f.Properties["directionRef"] = p.gps.ImgDirectionRef
+		}
+		fc.Features = append(fc.Features, f)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fc)
+}
+
+func writeKML(w io.Writer, photos []photo) error {
+	type data struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	}
+	type placemark struct {
+		Name        string `xml:"name"`
+		Description string `xml:"description,omitempty"`
+		When        string `xml:"TimeStamp>when,omitempty"`
+		Data        []data `xml:"ExtendedData>Data"`
+		Coordinates string `xml:"Point>coordinates"`
+	}
+	doc := struct {
+		XMLName    xml.Name    `xml:"http://www.opengis.net/kml/2.2 kml"`
+		Placemarks []placemark `xml:"Document>Placemark"`
+	}{}
+
+	for _, p := range photos {
+		pm := placemark{
+			Name:        p.name(),
+			Description: p.description(),
+			When:        p.time,
+			Data:        []data{{"filename", p.name()}, {"path", p.path}},
+			Coordinates: coordinate(p.gps.Longitude) + "," + coordinate(p.gps.Latitude),
+		}
+		if p.gps.Altitude != nil {
+			pm.Coordinates += "," + strconv.FormatFloat(*p.gps.Altitude, 'f', -1, 64)
+		}
+		if p.time != "" {
+			pm.Data = append(pm.Data, data{"time", p.time})
+		}
+		if p.camera != "" {
+			pm.Data = append(pm.Data, data{"camera", p.camera})
+		}
+		if p.gps.ImgDirection != nil {
+			pm.Data = append(pm.Data,
+				data{"direction", strconv.FormatFloat(*p.gps.ImgDirection, 'f', -1, 64)},
+				data{"directionRef", p.gps.ImgDirectionRef})
+		}
+		doc.Placemarks = append(doc.Placemarks, pm)
+	}
+	return writeXML(w, doc)
+}
+
+func writeGPX(w io.Writer, photos []photo) error {
+	// the elements of a waypoint are in the order of the GPX 1.1 schema
+	type wpt struct {
+		Lat  string   `xml:"lat,attr"`
+		Lon  string   `xml:"lon,attr"`
+		Ele  *float64 `xml:"ele,omitempty"`
+		Time string   `xml:"time,omitempty"`
+		Name string   `xml:"name"`
+		Desc string   `xml:"desc,omitempty"`
+	}
+	doc := struct {
+		XMLName   xml.Name `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+		Version   string   `xml:"version,attr"`
+		Creator   string   `xml:"creator,attr"`
+		Waypoints []wpt    `xml:"wpt"`
+	}{Version: "1.1", Creator: "exif"}
+
+	for _, p := range photos {
+		doc.Waypoints = append(doc.Waypoints, wpt{
+			Lat:  coordinate(p.gps.Latitude),
+			Lon:  coordinate(p.gps.Longitude),
+			Ele:  p.gps.Altitude,
+			Time: p.time,
+			Name: p.name(),
+			Desc: p.description(),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// coordinate formats decimal degrees, with a precision of about 1 cm.
+func coordinate(deg float64) string {
+	return strconv.FormatFloat(deg, 'f', 7, 64)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"exif/pkg/exif"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// testPhotos returns the position of DSCN0012.jpg and a photo with
+// every property, south-west of the origin and below sea level.
+func testPhotos(t *testing.T) []photo {
+	t.Helper()
+	p, err := readPhoto("../DSCN0012.jpg", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alt, dir := -12.5, 135.0
+	return []photo{*p, {
+		path: "trips/Rock & Roll <1>.jpg",
+		gps: &exif.GPS{
+			Latitude:        -33.8567844,
+			Longitude:       -70.6514,
+			Altitude:        &alt,
+			Time:            time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+			ImgDirection:    &dir,
+			ImgDirectionRef: "M",
+		},
+		time:   "2024-03-01T09:30:00-03:00",
+		camera: "Canon EOS R5",
+	}}
+}
+
+func TestExportGeo(t *testing.T) {
+	photos := testPhotos(t)
+	for _, tt := range []struct {
+		golden string
+		write  func(io.Writer, []photo) error
+	}{
+		{"photos.geojson", writeGeoJSON},
+		{"photos.kml", writeKML},
+		{"photos.gpx", writeGPX},
+	} {
+		t.Run(tt.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, photos); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestExportGeoEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGeoJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"type\": \"FeatureCollection\",\n  \"features\": []\n}\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
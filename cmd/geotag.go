@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"exif/pkg/exif"
+	"exif/pkg/track"
+)
+
+// runGeotag sets the GPS position of JPEG files from track logs,
+// rewriting them in place.
+func runGeotag(args []string) error {
+	fs := flag.NewFlagSet("geotag", flag.ExitOnError)
+	tracks := fs.String("track", "", "comma-separated GPX, KML or NMEA track logs")
+	offset := fs.String("offset", "", `camera clock error added to its times, e.g. "+1m30s" when it's late`)
+	tz := fs.String("tz", "", `time zone of the camera clock, e.g. "Europe/Paris" or "+02:00" (default UTC)`)
+	maxGap := fs.Duration("maxgap", 30*time.Minute, "largest gap between track points interpolated, or to the closest point")
+	overwrite := fs.Bool("overwrite", false, "replace the position of files that have one")
+	backup := fs.String("backup", ".orig", "suffix of the backup of each file, none when empty")
+	fs.Parse(args)
+
+	if *tracks == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("expect a track log and at least one file or directory")
+	}
+
+	opts := exif.GeotagOptions{MaxGap: *maxGap}
+	if *offset != "" {
+		d, err := exif.ParseShift(*offset)
+		if err != nil {
+			return err
+		}
+		opts.ClockOffset = d
+	}
+	if *tz != "" {
+		loc, err := parseZone(*tz)
+		if err != nil {
+			return err
+		}
+		opts.Location = loc
+	}
+
+	var logs []track.Track
+	for _, path := range strings.Split(*tracks, ",") {
+		t, err := track.ReadFile(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		logs = append(logs, t)
+	}
+	tr := track.Merge(logs...)
+	if len(tr) == 0 {
+		return fmt.Errorf("%s: %w", *tracks, track.ErrNoPoints)
+	}
+	fmt.Printf("%d track points from %s to %s\n", len(tr),
+		tr[0].Time.Format(time.RFC3339), tr[len(tr)-1].Time.Format(time.RFC3339))
+
+	paths, err := jpegFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, path := range paths {
+		g, err := geotagFile(path, tr, opts, *overwrite, *backup)
+		switch {
+		case err != nil:
+			fmt.Printf("%s: %v\n", path, err)
+			failed++
+		case g == nil:
+			fmt.Printf("%s: has a position, unchanged\n", path)
+		default:
+			fmt.Printf("%s: %.6f, %.6f\n", path, g.Latitude, g.Longitude)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	}
+	return nil
+}
+
+// geotagFile sets the position of the JPEG file at path from tr. It returns
+// a nil GPS, the file being unchanged, when it has a position and overwrite
+// is false.
+func geotagFile(path string, tr track.Track, opts exif.GeotagOptions, overwrite bool, backup string) (*exif.GPS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exif: %w", err)
+	}
+	if _, err := meta.GPSInfo(); err == nil && !overwrite {
+		return nil, nil
+	}
+
+	g, err := meta.Geotag(tr, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := exif.WriteJPEG(&buf, bytes.NewReader(data), meta); err != nil {
+		return nil, err
+	}
+	return g, writeInPlace(path, buf.Bytes(), backup)
+}
+
+// parseZone returns the location called name, or the fixed zone
+// of an offset such as "+02:00".
+func parseZone(name string) (*time.Location, error) {
+	// time.Parse gives time.Local when the offset is the local one,
+	// whose offset may differ at other dates
+	if t, err := time.Parse("-07:00", name); err == nil {
+		_, offset := t.Zone()
+		return time.FixedZone(name, offset), nil
+	}
+	return time.LoadLocation(name)
+}
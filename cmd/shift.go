@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"exif/pkg/exif"
+)
+
+// runShift adds a time offset to the date and times of JPEG files,
+// rewriting them in place.
+func runShift(args []string) error {
+	fs := flag.NewFlagSet("shift", flag.ExitOnError)
+	by := fs.String("by", "", `time offset, e.g. "+1h30m" or "-2d"`)
+	backup := fs.String("backup", ".orig", "suffix of the backup of each file, none when empty")
+	fs.Parse(args)
+
+	if *by == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("expect an offset and at least one file or directory")
+	}
+	d, err := exif.ParseShift(*by)
+	if err != nil {
+		return err
+	}
+	paths, err := jpegFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, path := range paths {
+		n, err := shiftFile(path, d, *backup)
+		switch {
+		case err != nil:
+			fmt.Printf("%s: %v\n", path, err)
+			failed++
+		case n == 0:
+			fmt.Printf("%s: no date and time, unchanged\n", path)
+		default:
+			fmt.Printf("%s: %d date and times shifted by %s\n", path, n, d)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	}
+	return nil
+}
+
+// shiftFile shifts the date and times of the JPEG file at path by d and
+// returns how many were shifted; the file isn't written when there is none.
+func shiftFile(path string, d time.Duration, backup string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	meta, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode exif: %w", err)
+	}
+	n, err := meta.Shift(d)
+	if err != nil || n == 0 {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	if err := exif.WriteJPEG(&buf, bytes.NewReader(data), meta); err != nil {
+		return 0, err
+	}
+	return n, writeInPlace(path, buf.Bytes(), backup)
+}
+
+// writeInPlace replaces the file at path with data, through a temporary
+// file of the same directory. When backup isn't empty the original file
+// is kept as path+backup, see backupName.
+func writeInPlace(path string, data []byte, backup string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if backup == "" {
+		return os.Rename(tmp.Name(), path)
+	}
+	name, err := backupName(path, backup)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(path, name); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		// the original goes back in place
+		if err := os.Rename(name, path); err != nil {
+			return fmt.Errorf("failed to restore %s from %s: %w", path, name, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// backupName returns path+backup, or path.n+backup when it exists, so that
+// a backup of an earlier run, likely the original file, is never replaced.
+func backupName(path, backup string) (string, error) {
+	name := path + backup
+	for n := 1; ; n++ {
+		if _, err := os.Lstat(name); errors.Is(err, os.ErrNotExist) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+		name = fmt.Sprintf("%s.%d%s", path, n, backup)
+	}
+}
+
+// jpegFiles returns the files of args, directories being replaced
+// by the JPEG files they contain.
+func jpegFiles(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".jpg", ".jpeg":
+				if e.Type().IsRegular() {
+					paths = append(paths, filepath.Join(arg, e.Name()))
+				}
+			}
+		}
+	}
+	return paths, nil
+}
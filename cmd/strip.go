@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"exif/pkg/exif"
+	"exif/pkg/tag"
+)
+
+// runStrip writes a copy of a JPEG file without the selected metadata.
+func runStrip(args []string) error {
+	fs := flag.NewFlagSet("strip", flag.ExitOnError)
+	out := fs.String("o", "", "output file (default: <file>.stripped.jpg)")
+	privacy := fs.Bool("privacy", false, "strip GPS, serials, owner, maker notes, thumbnails, XMP, IPTC and comments")
+	keep := fs.String("keep", "", "comma-separated tag names to keep, every other tag is removed")
+	var opts exif.StripOptions
+	fs.BoolVar(&opts.Exif, "exif", false, "strip the whole Exif APP1 segment")
+	fs.BoolVar(&opts.GPS, "gps", false, "strip the GPS IFD")
+	fs.BoolVar(&opts.Serials, "serials", false, "strip serial numbers")
+	fs.BoolVar(&opts.Owner, "owner", false, "strip owner and artist")
+	fs.BoolVar(&opts.MakerNotes, "makernotes", false, "strip maker notes")
+	fs.BoolVar(&opts.Thumbnail, "thumbnail", false, "strip thumbnails")
+	fs.BoolVar(&opts.XMP, "xmp", false, "strip XMP segments")
+	fs.BoolVar(&opts.IPTC, "iptc", false, "strip IPTC segments")
+	fs.BoolVar(&opts.Comments, "comments", false, "strip COM segments")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expect exactly one file")
+	}
+	path := fs.Arg(0)
+
+	if *privacy {
+		// the preset strips everything but the whole Exif APP1
+		all := opts.Exif
+		opts = exif.StripPrivacy
+		opts.Exif = all
+	}
+	if *keep != "" {
+		for _, name := range strings.Split(*keep, ",") {
+			t, ok := lookupTag(strings.TrimSpace(name))
+			if !ok {
+				return fmt.Errorf("unknown tag %q", name)
+			}
+			opts.Keep = append(opts.Keep, t)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := exif.Strip(&buf, bytes.NewReader(data), opts); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		*out = strings.TrimSuffix(path, filepath.Ext(path)) + ".stripped.jpg"
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("stripped image written to %s (%d bytes, was %d)\n", *out, buf.Len(), len(data))
+	return nil
+}
+
+// lookupTag returns the tag called name, looked up in every namespace.
+func lookupTag(name string) (tag.Tag, bool) {
+	for _, ns := range []tag.IFD{tag.IFDTIFF, tag.IFDExif, tag.IFDGPS, tag.IFDInterop} {
+		if info, ok := tag.LookupName(ns, name); ok {
+			return tag.Key{IFD: info.IFD, ID: info.ID}, true
+		}
+	}
+	return nil, false
+}
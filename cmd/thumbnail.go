@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+
+	"exif/pkg/exif"
+	"exif/pkg/tag"
+)
+
+// runThumbnail writes the embedded thumbnail of a file: the Exif IFD1
+// thumbnail, or the JFXX one. JPEG thumbnails are written as is,
+// uncompressed ones are encoded to PNG.
+func runThumbnail(args []string) error {
+	fs := flag.NewFlagSet("thumbnail", flag.ExitOnError)
+	out := fs.String("o", "", "output file (default: <file>.thumb.jpg or .png)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expect exactly one file")
+	}
+	path := fs.Arg(0)
+
+	meta, err := exif.DecodeFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to decode exif: %w", err)
+	}
+
+	th, err := meta.Thumbnail()
+	if errors.Is(err, exif.ErrNoThumbnail) && meta.JFXX != nil {
+		return writeJFXXThumbnail(meta.JFXX, path, *out)
+	}
+	if err != nil {
+		return err
+	}
+
+	if th.Compression != tag.CompressionNone {
+		return writeOut(path, *out, ".thumb.jpg", th.Data)
+	}
+
+	img, err := th.Image()
+	if err != nil {
+		return err
+	}
+	return writePNG(path, *out, img)
+}
+
+func writeJFXXThumbnail(app0 *exif.APP0, path, out string) error {
+	if app0.ExtensionCode == exif.ExtensionCode_JPEG {
+		return writeOut(path, out, ".thumb.jpg", app0.JPEGThumbnail)
+	}
+
+	img, err := app0.Thumbnail()
+	if err != nil {
+		return err
+	}
+	return writePNG(path, out, img)
+}
+
+func writeOut(path, out, ext string, data []byte) error {
+	if out == "" {
+		out = path + ext
+	}
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("thumbnail written to %s (%d bytes)\n", out, len(data))
+	return nil
+}
+
+func writePNG(path, out string, img image.Image) error {
+	if out == "" {
+		out = path + ".thumb.png"
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = png.Encode(f, img); err != nil {
+		return err
+	}
+	fmt.Printf("thumbnail written to %s\n", out)
+	return nil
+}
@@ -0,0 +1,199 @@
+package exif
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// Errors returned by DateTime and ParseDateTime.
+var (
+	// the date and time tag is absent, blank or zero ("0000:00:00 00:00:00")
+	ErrNoDateTime = errors.New("no date and time")
+
+	// the date and time, its fractions of seconds or its offset can't be parsed
+	ErrDateTime = errors.New("malformed date and time")
+)
+
+// dateTimeLayout is the layout of the date and time tags.
+const dateTimeLayout = "2006:01:02 15:04:05"
+
+// DateTimeTags are the tags of a date and time: the date and time
+// ("2008:11:01 21:15:07"), its fractions of seconds ("123") and its
+// time zone offset ("+02:00", Exif 2.31).
+type DateTimeTags struct {
+	DateTime tag.Exif
+	SubSec   tag.Exif
+	Offset   tag.Exif
+}
+
+// The date and times of the metadata: when the file was changed, when the
+// picture was taken and when it was stored as digital data (DateTimeDigitized).
+var (
+	ModifyDateTags       = DateTimeTags{tag.ModifyDate, tag.SubSecTime, tag.OffsetTime}
+	DateTimeOriginalTags = DateTimeTags{tag.DateTimeOriginal, tag.SubSecTimeOriginal, tag.OffsetTimeOriginal}
+	CreateDateTags       = DateTimeTags{tag.CreateDate, tag.SubSecTimeDigitized, tag.OffsetTimeDigitized}
+)
+
+// ModifyDate returns the date and time the file was changed, see DateTime.
+func (m *Metadata) ModifyDate(loc *time.Location) (time.Time, error) {
+	return m.DateTime(ModifyDateTags, loc)
+}
+
+// DateTimeOriginal returns the date and time the picture was taken, see DateTime.
+func (m *Metadata) DateTimeOriginal(loc *time.Location) (time.Time, error) {
+	return m.DateTime(DateTimeOriginalTags, loc)
+}
+
+// CreateDate returns the date and time the picture was stored as digital
+// data, see DateTime.
+func (m *Metadata) CreateDate(loc *time.Location) (time.Time, error) {
+	return m.DateTime(CreateDateTags, loc)
+}
+
+// DateTime returns the date and time of tags d, in the time zone of its
+// offset tag or, when it has none, in loc (UTC when nil). It returns
+// ErrNoDateTime when the date and time is absent, blank or zero,
+// see ParseDateTime for the values accepted.
+func (m *Metadata) DateTime(d DateTimeTags, loc *time.Location) (time.Time, error) {
+	ascii := func(t tag.Exif) string {
+		e, _ := m.Get(t)
+		s, _ := e.Value.(string)
+		return s
+	}
+	t, err := ParseDateTime(ascii(d.DateTime), ascii(d.SubSec), ascii(d.Offset), loc)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "%s", d.DateTime)
+	}
+	return t, nil
+}
+
+// SetDateTime sets the tags d to t: its date and time and offset in the
+// location of t, and its fractions of seconds, the SubSec tag being
+// removed when t has none.
+func (m *Metadata) SetDateTime(d DateTimeTags, t time.Time) error {
+	return m.setDateTime(d, t, true)
+}
+
+// setDateTime is SetDateTime, the offset tag being removed instead
+// of written when withOffset is false.
+func (m *Metadata) setDateTime(d DateTimeTags, t time.Time, withOffset bool) error {
+	dt, subSec, offset := FormatDateTime(t)
+	if err := m.Set(d.DateTime, dt); err != nil {
+		return err
+	}
+	if subSec == "" {
+		m.Delete(d.SubSec)
+	} else if err := m.Set(d.SubSec, subSec); err != nil {
+		return err
+	}
+	if !withOffset {
+		m.Delete(d.Offset)
+		return nil
+	}
+	return m.Set(d.Offset, offset)
+}
+
+// ParseDateTime parses the values of a date and time, its fractions of
+// seconds and its time zone offset. subSec and offset may be empty; without
+// offset the time is in loc (UTC when nil).
+//
+// Values written by some software are accepted: the fields may be separated
+// by other characters than ':' ("2008-11-01T21:15:07"), padded with spaces
+// ("2008:11:01  9:15:07") and the seconds may be missing. Blank values and
+// ones whose fields are all zero or blank ("0000:00:00 00:00:00",
+// "    :  :     :  :  ") give ErrNoDateTime.
+func ParseDateTime(dt, subSec, offset string, loc *time.Location) (time.Time, error) {
+	if strings.Trim(dt, "0: \x00") == "" {
+		return time.Time{}, ErrNoDateTime
+	}
+	fields := strings.FieldsFunc(dt, func(r rune) bool { return r < '0' || r > '9' })
+	if len(fields) != 5 && len(fields) != 6 {
+		return time.Time{}, errors.Wrapf(ErrDateTime, "%q", dt)
+	}
+	n := make([]int, 6)
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(ErrDateTime, "%q", dt)
+		}
+		n[i] = v
+	}
+
+	var nsec int
+	if subSec = strings.TrimSpace(strings.TrimRight(subSec, "\x00")); subSec != "" {
+		if !isDigits(subSec) {
+			return time.Time{}, errors.Wrapf(ErrDateTime, "fractions of seconds %q", subSec)
+		}
+		// nanoseconds are the first 9 digits, right padded with zeros
+		subSec = (subSec + "000000000")[:9]
+		nsec, _ = strconv.Atoi(subSec)
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	if zone, ok, err := parseOffset(offset); err != nil {
+		return time.Time{}, err
+	} else if ok {
+		loc = zone
+	}
+
+	t := time.Date(n[0], time.Month(n[1]), n[2], n[3], n[4], n[5], nsec, loc)
+	// time.Date normalizes out of range fields, 2008:02:30 being March 1st
+	if t.Year() != n[0] || int(t.Month()) != n[1] || t.Day() != n[2] ||
+		t.Hour() != n[3] || t.Minute() != n[4] || t.Second() != n[5] {
+		return time.Time{}, errors.Wrapf(ErrDateTime, "%q", dt)
+	}
+	return t, nil
+}
+
+// parseOffset parses a time zone offset, "+02:00", "-0530" or "Z". It returns
+// false when offset is blank ("", "   :  ").
+func parseOffset(offset string) (*time.Location, bool, error) {
+	s := strings.TrimSpace(strings.TrimRight(offset, "\x00"))
+	if strings.Trim(s, " :") == "" {
+		return nil, false, nil
+	}
+	if s == "Z" {
+		return time.UTC, true, nil
+	}
+
+	s = strings.ReplaceAll(s, ":", "")
+	if len(s) != 5 || (s[0] != '+' && s[0] != '-') || !isDigits(s[1:]) {
+		return nil, false, errors.Wrapf(ErrDateTime, "offset %q", offset)
+	}
+	h, _ := strconv.Atoi(s[1:3])
+	m, _ := strconv.Atoi(s[3:])
+	if h > 14 || m > 59 {
+		return nil, false, errors.Wrapf(ErrDateTime, "offset %q", offset)
+	}
+	sec := h*3600 + m*60
+	if s[0] == '-' {
+		sec = -sec
+	}
+	return time.FixedZone("", sec), true, nil
+}
+
+// FormatDateTime returns the values of the date and time tags for t in its
+// location: "2008:11:01 21:15:07", the fractions of seconds without trailing
+// zeros ("" when t has none) and the offset, "+01:00".
+func FormatDateTime(t time.Time) (dt, subSec, offset string) {
+	if ns := t.Nanosecond(); ns != 0 {
+		subSec = strings.TrimRight(strconv.Itoa(ns + 1e9)[1:], "0")
+	}
+	return t.Format(dateTimeLayout), subSec, t.Format("-07:00")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
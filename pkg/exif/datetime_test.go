@@ -0,0 +1,125 @@
+package exif
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"exif/pkg/tag"
+)
+
+func TestParseDateTime(t *testing.T) {
+	paris := time.FixedZone("", 3600)
+	tests := []struct {
+		dt, subSec, offset string
+		loc                *time.Location
+		want               time.Time
+		err                error
+	}{
+		{"2008:11:01 21:15:07", "", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 0, time.UTC), nil},
+		{"2008:11:01 21:15:07", "", "", paris, time.Date(2008, 11, 1, 21, 15, 7, 0, paris), nil},
+		{"2008:11:01 21:15:07\x00", "", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 0, time.UTC), nil},
+		{"2008-11-01T21:15:07", "", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 0, time.UTC), nil},
+		{"2008:11:01  9:15:07", "", "", nil, time.Date(2008, 11, 1, 9, 15, 7, 0, time.UTC), nil},
+		{"2008:11:01 21:15", "", "", nil, time.Date(2008, 11, 1, 21, 15, 0, 0, time.UTC), nil},
+
+		// fractions of seconds
+		{"2008:11:01 21:15:07", "5", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 500e6, time.UTC), nil},
+		{"2008:11:01 21:15:07", "050", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 50e6, time.UTC), nil},
+		{"2008:11:01 21:15:07", "123456789123", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 123456789, time.UTC), nil},
+		{"2008:11:01 21:15:07", " 42\x00", "", nil, time.Date(2008, 11, 1, 21, 15, 7, 420e6, time.UTC), nil},
+		{"2008:11:01 21:15:07", "4a", "", nil, time.Time{}, ErrDateTime},
+
+		// offsets, which take precedence over loc
+		{"2008:11:01 21:15:07", "", "+02:00", paris, time.Date(2008, 11, 1, 19, 15, 7, 0, time.UTC), nil},
+		{"2008:11:01 21:15:07", "", "-0530", nil, time.Date(2008, 11, 2, 2, 45, 7, 0, time.UTC), nil},
+		{"2008:11:01 21:15:07", "", "Z", paris, time.Date(2008, 11, 1, 21, 15, 7, 0, time.UTC), nil},
+		{"2008:11:01 21:15:07", "", "   :  ", paris, time.Date(2008, 11, 1, 21, 15, 7, 0, paris), nil},
+		{"2008:11:01 21:15:07", "25", "+01:00", nil, time.Date(2008, 11, 1, 20, 15, 7, 250e6, time.UTC), nil},
+		{"2008:11:01 21:15:07", "", "+15:00", nil, time.Time{}, ErrDateTime},
+		{"2008:11:01 21:15:07", "", "02:00", nil, time.Time{}, ErrDateTime},
+		{"2008:11:01 21:15:07", "", "+02:60", nil, time.Time{}, ErrDateTime},
+
+		// missing and invalid values
+		{"", "", "", nil, time.Time{}, ErrNoDateTime},
+		{"0000:00:00 00:00:00", "", "", nil, time.Time{}, ErrNoDateTime},
+		{"    :  :     :  :  ", "", "", nil, time.Time{}, ErrNoDateTime},
+		{"garbage", "", "", nil, time.Time{}, ErrDateTime},
+		{"2008:02:30 12:00:00", "", "", nil, time.Time{}, ErrDateTime},
+		{"2008:11:01 24:00:00", "", "", nil, time.Time{}, ErrDateTime},
+		{"2008:11:01 21:15:07:01", "", "", nil, time.Time{}, ErrDateTime},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateTime(tt.dt, tt.subSec, tt.offset, tt.loc)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ParseDateTime(%q, %q, %q): got %v, want %v", tt.dt, tt.subSec, tt.offset, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDateTime(%q, %q, %q): %v", tt.dt, tt.subSec, tt.offset, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Nanosecond() != tt.want.Nanosecond() {
+			t.Errorf("ParseDateTime(%q, %q, %q) = %v, want %v", tt.dt, tt.subSec, tt.offset, got, tt.want)
+		}
+		// without offset the time is in loc
+		if _, hasOffset, _ := parseOffset(tt.offset); !hasOffset && got.Location() != tt.want.Location() {
+			t.Errorf("ParseDateTime(%q, %q, %q) in %v, want %v", tt.dt, tt.subSec, tt.offset, got.Location(), tt.want.Location())
+		}
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	tests := []struct {
+		t                  time.Time
+		dt, subSec, offset string
+	}{
+		{time.Date(2008, 11, 1, 21, 15, 7, 0, time.UTC), "2008:11:01 21:15:07", "", "+00:00"},
+		{time.Date(2008, 11, 1, 21, 15, 7, 50e6, time.FixedZone("", -5*3600-1800)), "2008:11:01 21:15:07", "05", "-05:30"},
+		{time.Date(2008, 1, 2, 3, 4, 5, 123456789, time.FixedZone("", 3600)), "2008:01:02 03:04:05", "123456789", "+01:00"},
+	}
+	for _, tt := range tests {
+		dt, subSec, offset := FormatDateTime(tt.t)
+		if dt != tt.dt || subSec != tt.subSec || offset != tt.offset {
+			t.Errorf("FormatDateTime(%v) = %q, %q, %q, want %q, %q, %q", tt.t, dt, subSec, offset, tt.dt, tt.subSec, tt.offset)
+		}
+		got, err := ParseDateTime(dt, subSec, offset, nil)
+		if err != nil || !got.Equal(tt.t) {
+			t.Errorf("ParseDateTime(FormatDateTime(%v)) = %v, %v", tt.t, got, err)
+		}
+	}
+}
+
+func TestSetDateTime(t *testing.T) {
+	m := &Metadata{}
+	want := time.Date(2020, 2, 29, 23, 59, 59, 250e6, time.FixedZone("", -7*3600))
+	if err := m.SetDateTime(DateTimeOriginalTags, want); err != nil {
+		t.Fatal(err)
+	}
+	for tg, v := range map[tag.Tag]string{
+		tag.DateTimeOriginal:   "2020:02:29 23:59:59",
+		tag.SubSecTimeOriginal: "25",
+		tag.OffsetTimeOriginal: "-07:00",
+	} {
+		if e, _ := m.Get(tg); e.Value != v {
+			t.Errorf("%v = %#v, want %q", tg, e.Value, v)
+		}
+	}
+	got, err := m.DateTimeOriginal(nil)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("DateTimeOriginal() = %v, %v, want %v", got, err, want)
+	}
+
+	// whole seconds remove SubSec
+	if err := m.SetDateTime(DateTimeOriginalTags, want.Truncate(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Get(tag.SubSecTimeOriginal); ok {
+		t.Error("SubSecTimeOriginal not removed")
+	}
+	if _, err := m.CreateDate(nil); !errors.Is(err, ErrNoDateTime) {
+		t.Errorf("CreateDate() error %v, want ErrNoDateTime", err)
+	}
+}
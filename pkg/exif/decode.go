@@ -0,0 +1,140 @@
+// Package exif reads Exif metadata (TIFF IFDs stored in a JPEG APP1
+// segment, or in a TIFF file) into a structured Metadata value.
+package exif
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoExif is returned by Decode when the image carries neither
+// an Exif APP1 segment nor a JFIF APP0 segment, and by ParseAPP1
+// when its segment isn't an Exif one.
+var ErrNoExif = errors.New("no exif APP1 segment found")
+
+// Metadata is the decoded Exif content of an image.
+type Metadata struct {
+	// byte order of the TIFF header ('II' or 'MM')
+	ByteOrder EndianType `json:"byteOrder"`
+
+	// IFD0 entries
+	IFD0 *IFD `json:"ifd0"`
+
+	// Exif sub-IFD entries (nil when IFD0 has no ExifOffset)
+	Exif *IFD `json:"exif,omitempty"`
+
+	// GPS IFD entries (nil when IFD0 has no GPSInfo)
+	GPS *IFD `json:"gps,omitempty"`
+
+	// Interoperability IFD entries (nil when the Exif
+	// sub-IFD has no InteropOffset)
+	Interop *IFD `json:"interop,omitempty"`
+
+	// IFD1 (thumbnail) entries (nil when IFD0 is the last IFD)
+	IFD1 *IFD `json:"ifd1,omitempty"`
+
+	// every IFD of the main chain, starting with IFD0 and IFD1;
+	// for multi-page TIFF files there is one per page
+	Chain []*IFD `json:"-"`
+
+	// errors of the IFDs that failed to parse, IFD0 excepted: the sub-IFD
+	// is left nil, the chain stops before the IFD
+	Warnings []error `json:"-"`
+
+	// JFIF APP0 segment (nil when absent)
+	JFIF *APP0 `json:"jfif,omitempty"`
+
+	// JFIF extension APP0 segment, carrying a thumbnail (nil when absent)
+	JFXX *APP0 `json:"jfxx,omitempty"`
+
+	// TIFF data, starting with the TIFF header, offsets of the IFDs
+	// and of their entries are relative to it
+	tiff *tiffSource
+}
+
+// Decode reads a JPEG or TIFF image from r and returns its Exif and JFIF metadata.
+// JFIF and JFXX segments that fail to parse are ignored, leaving JFIF or JFXX nil.
+func Decode(r io.Reader) (*Metadata, error) {
+	br := bufio.NewReader(r)
+	if isTIFF(br) {
+		return DecodeTIFF(br)
+	}
+
+	// metadata segments all come before SOS, where ReadSegments stops
+	segs, err := ReadSegments(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta *Metadata
+	if app1 := segs.Exif(); app1 != nil {
+		if meta, err = ParseAPP1(SegmentBytes(app1)); err != nil {
+			return nil, err
+		}
+	}
+
+	app0 := make(map[string]*APP0)
+	for _, sig := range []string{SignatureJFIF, SignatureJFXX} {
+		seg := segs.APP(MarkerAPP0, sig)
+		if seg == nil {
+			continue
+		}
+		// a malformed JFIF or JFXX segment doesn't prevent reading the Exif
+		// metadata, it's left out
+		if a, err := ParseAPP0(SegmentBytes(seg)); err == nil {
+			app0[sig] = a
+		}
+	}
+
+	if meta == nil {
+		if len(app0) == 0 {
+			return nil, ErrNoExif
+		}
+		meta = &Metadata{}
+	}
+	meta.JFIF = app0[SignatureJFIF]
+	meta.JFXX = app0[SignatureJFXX]
+	return meta, nil
+}
+
+// DecodeFile opens the file at path and decodes its Exif metadata.
+// TIFF files are read in place, so BigTIFF files over 4 GB aren't loaded.
+func DecodeFile(path string) (*Metadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	var sig [4]byte
+	if _, err := f.ReadAt(sig[:], 0); err == nil && hasTIFFSignature(sig[:]) {
+		fi, err := f.Stat()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stat file")
+		}
+		meta, err := DecodeTIFFAt(f, fi.Size())
+		if err != nil {
+			return nil, err
+		}
+		// f is closed on return, the thumbnail is read by reopening path
+		meta.tiff.r = fileReaderAt(path)
+		return meta, nil
+	}
+
+	return Decode(f)
+}
+
+// fileReaderAt reads the file at its path, opening it for each read.
+type fileReaderAt string
+
+func (p fileReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	f, err := os.Open(string(p))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return f.ReadAt(b, off)
+}
@@ -0,0 +1,387 @@
+package exif
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// Errors returned when editing metadata.
+var (
+	// the tag isn't part of the registry of package tag
+	ErrUnknownTag = errors.New("unknown tag")
+
+	// the tag is a pointer or an offset written by the encoder
+	ErrManagedTag = errors.New("tag is written by the encoder")
+
+	// the number of values doesn't match the registry
+	ErrCount = errors.New("wrong value count")
+
+	// the Metadata to edit is nil
+	ErrNilMetadata = errors.New("nil metadata")
+)
+
+// ifdOf returns the field of m holding the IFD of namespace ns.
+func (m *Metadata) ifdOf(ns tag.IFD) **IFD {
+	switch ns {
+	case tag.IFDTIFF:
+		return &m.IFD0
+	case tag.IFDExif:
+		return &m.Exif
+	case tag.IFDGPS:
+		return &m.GPS
+	case tag.IFDInterop:
+		return &m.Interop
+	}
+	return nil
+}
+
+// Get returns the first entry of tag t, looked up in IFD0 for the TIFF tags.
+func (m *Metadata) Get(t tag.Tag) (IfdEntry, bool) {
+	if m == nil {
+		return IfdEntry{}, false
+	}
+	key := t.Key()
+	if p := m.ifdOf(key.IFD); p != nil {
+		return (*p).Get(key.ID)
+	}
+	return IfdEntry{}, false
+}
+
+// Set sets the value of tag t, replacing its entries if any. TIFF tags go
+// to IFD0, the IFD of other namespaces is created when missing.
+//
+// The value is checked against the types and count of the registry and
+// converted to the Go type decodeValue uses for the chosen field type:
+// a string for ASCII, a []byte for BYTE and UNDEFINED, integers or slices
+// of integers for BYTE, SHORT, LONG, SBYTE, SSHORT and SLONG (the first type
+// of the registry the values fit in is used), tag.Rational, tag.SRational
+// or pairs of integers (numerator, denominator) and their slices for RATIONAL
+// and SRATIONAL, and floats for FLOAT and DOUBLE, or for RATIONAL and SRATIONAL
+// through tag.RationalFromFloat.
+func (m *Metadata) Set(t tag.Tag, value any) error {
+	if m == nil {
+		return ErrNilMetadata
+	}
+	key := t.Key()
+	info, ok := tag.Lookup(key.IFD, key.ID)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTag, "%s 0x%04X", key.IFD, key.ID)
+	}
+	if pointerTags[key.IFD][key.ID] || (key.IFD == tag.IFDTIFF && dataTags[key.ID]) {
+		return errors.Wrapf(ErrManagedTag, "%s", info.Name)
+	}
+
+	tp, v, cnt, err := convertValue(info, value)
+	if err != nil {
+		return errors.Wrapf(err, "%s", info.Name)
+	}
+	if info.Count != 0 && cnt != uint64(info.Count) {
+		return errors.Wrapf(ErrCount, "%s has %d values, want %d", info.Name, cnt, info.Count)
+	}
+
+	p := m.ifdOf(key.IFD)
+	if *p == nil {
+		*p = &IFD{Namespace: key.IFD}
+		switch key.IFD {
+		case tag.IFDTIFF:
+			m.Chain = append([]*IFD{m.IFD0}, m.Chain...)
+		case tag.IFDInterop:
+			// the Interoperability IFD is linked by the Exif sub-IFD
+			if m.Exif == nil {
+				m.Exif = &IFD{Namespace: tag.IFDExif}
+			}
+		}
+	}
+	ifd := *p
+
+	entry := IfdEntry{
+		TagID:  key.ID,
+		TypeID: uint16(tp),
+		Count:  cnt,
+		Value:  v,
+		Name:   info.Name,
+		Index:  len(ifd.Entries),
+	}
+	replaced := false
+	entries := ifd.Entries[:0]
+	for _, e := range ifd.Entries {
+		if e.TagID != key.ID {
+			entries = append(entries, e)
+			continue
+		}
+		if !replaced {
+			entry.Index, entry.Offset = e.Index, e.Offset
+			entries = append(entries, entry)
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+	ifd.Entries = entries
+	return nil
+}
+
+// SetRational sets tag t, a RATIONAL or SRATIONAL tag of one value,
+// to num/den.
+func (m *Metadata) SetRational(t tag.Tag, num, den int64) error {
+	return m.Set(t, [][2]int64{{num, den}})
+}
+
+// Delete removes the entries of tag t and reports whether there were any.
+// Deleting a pointer tag removes the IFD it points to: ExifOffset removes
+// the Exif and Interoperability IFDs, GPSInfo the GPS IFD and
+// InteropOffset the Interoperability IFD.
+func (m *Metadata) Delete(t tag.Tag) bool {
+	if m == nil {
+		return false
+	}
+
+	key := t.Key()
+	if pointerTags[key.IFD][key.ID] {
+		var deleted bool
+		switch key.ID {
+		case uint16(tag.ExifOffset):
+			deleted = m.Exif != nil
+			m.Exif, m.Interop = nil, nil
+		case uint16(tag.GPSInfo):
+			deleted = m.GPS != nil
+			m.GPS = nil
+		case uint16(tag.InteropOffset):
+			deleted = m.Interop != nil
+			m.Interop = nil
+		}
+		return deleted
+	}
+
+	p := m.ifdOf(key.IFD)
+	if p == nil || *p == nil {
+		return false
+	}
+	ifd := *p
+	entries := ifd.Entries[:0]
+	for _, e := range ifd.Entries {
+		if e.TagID != key.ID {
+			entries = append(entries, e)
+		}
+	}
+	deleted := len(entries) != len(ifd.Entries)
+	ifd.Entries = entries
+	return deleted
+}
+
+// convertValue converts v to the Go value of one of the field types of info,
+// it returns the field type, the value and its count.
+func convertValue(info tag.Info, v any) (tag.Type, any, uint64, error) {
+	has := func(tp tag.Type) bool {
+		for _, t := range info.Types {
+			if t == tp {
+				return true
+			}
+		}
+		return false
+	}
+	mismatch := errors.Wrapf(ErrValueType, "%T value for a %v field", v, info.Types)
+
+	switch v := v.(type) {
+	case nil:
+		return 0, nil, 0, mismatch
+
+	case string:
+		if !has(tag.ASCII) {
+			return 0, nil, 0, mismatch
+		}
+		return tag.ASCII, v, uint64(len(v) + 1), nil
+
+	case []byte:
+		for _, tp := range info.Types {
+			if tp == tag.BYTE || tp == tag.UNDEFINED {
+				return tp, v, uint64(len(v)), nil
+			}
+		}
+		return 0, nil, 0, mismatch
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		// a single value
+		rv = reflect.Append(reflect.MakeSlice(reflect.SliceOf(rv.Type()), 0, 1), rv)
+	}
+	n := rv.Len()
+	elem := rv.Type().Elem()
+
+	switch {
+	case isInt(elem.Kind()):
+		ints := make([]int64, n)
+		for i := range ints {
+			ints[i] = intOf(rv.Index(i))
+		}
+		for _, tp := range info.Types {
+			if cv, ok := intsAs(tp, ints); ok {
+				return tp, cv, uint64(n), nil
+			}
+		}
+		return 0, nil, 0, errors.Wrapf(ErrValueType, "%T value %v for a %v field", v, v, info.Types)
+
+	case elem == reflect.TypeOf(tag.Rational{}) || elem == reflect.TypeOf(tag.SRational{}) ||
+		(elem.Kind() == reflect.Array && elem.Len() == 2 && isInt(elem.Elem().Kind())):
+		rats := make([][2]int64, n)
+		for i := range rats {
+			r := rv.Index(i)
+			if r.Kind() == reflect.Struct {
+				rats[i] = [2]int64{intOf(r.Field(0)), intOf(r.Field(1))}
+			} else {
+				rats[i] = [2]int64{intOf(r.Index(0)), intOf(r.Index(1))}
+			}
+		}
+		for _, tp := range info.Types {
+			if cv, ok := rationalsAs(tp, rats); ok {
+				return tp, cv, uint64(n), nil
+			}
+		}
+		return 0, nil, 0, errors.Wrapf(ErrValueType, "%T value %v for a %v field", v, v, info.Types)
+
+	case elem.Kind() == reflect.Float32 || elem.Kind() == reflect.Float64:
+		for _, tp := range info.Types {
+			switch tp {
+			case tag.FLOAT:
+				f := make([]float32, n)
+				for i := range f {
+					f[i] = float32(rv.Index(i).Float())
+				}
+				return tp, f, uint64(n), nil
+			case tag.DOUBLE:
+				f := make([]float64, n)
+				for i := range f {
+					f[i] = rv.Index(i).Float()
+				}
+				return tp, f, uint64(n), nil
+			case tag.RATIONAL:
+				rats := make([]tag.Rational, n)
+				for i := range rats {
+					rats[i] = tag.RationalFromFloat(rv.Index(i).Float())
+				}
+				return tp, rats, uint64(n), nil
+			case tag.SRATIONAL:
+				rats := make([]tag.SRational, n)
+				for i := range rats {
+					rats[i] = tag.SRationalFromFloat(rv.Index(i).Float())
+				}
+				return tp, rats, uint64(n), nil
+			}
+		}
+	}
+	return 0, nil, 0, mismatch
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uint64
+}
+
+// intOf returns the value of an integer, unsigned values above
+// math.MaxInt64 are clamped.
+func intOf(v reflect.Value) int64 {
+	if v.CanInt() {
+		return v.Int()
+	}
+	return int64(min(v.Uint(), math.MaxInt64))
+}
+
+// intsAs returns ints as the Go value of field type tp, if they all fit.
+func intsAs(tp tag.Type, ints []int64) (any, bool) {
+	fits := func(lo, hi int64) bool {
+		for _, x := range ints {
+			if x < lo || x > hi {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch tp {
+	case tag.BYTE, tag.UNDEFINED:
+		if !fits(0, math.MaxUint8) {
+			return nil, false
+		}
+		out := make([]byte, len(ints))
+		for i, x := range ints {
+			out[i] = byte(x)
+		}
+		return out, true
+	case tag.SHORT:
+		if !fits(0, math.MaxUint16) {
+			return nil, false
+		}
+		out := make([]uint16, len(ints))
+		for i, x := range ints {
+			out[i] = uint16(x)
+		}
+		return out, true
+	case tag.LONG:
+		if !fits(0, math.MaxUint32) {
+			return nil, false
+		}
+		out := make([]uint32, len(ints))
+		for i, x := range ints {
+			out[i] = uint32(x)
+		}
+		return out, true
+	case tag.SBYTE:
+		if !fits(math.MinInt8, math.MaxInt8) {
+			return nil, false
+		}
+		out := make([]int8, len(ints))
+		for i, x := range ints {
+			out[i] = int8(x)
+		}
+		return out, true
+	case tag.SSHORT:
+		if !fits(math.MinInt16, math.MaxInt16) {
+			return nil, false
+		}
+		out := make([]int16, len(ints))
+		for i, x := range ints {
+			out[i] = int16(x)
+		}
+		return out, true
+	case tag.SLONG:
+		if !fits(math.MinInt32, math.MaxInt32) {
+			return nil, false
+		}
+		out := make([]int32, len(ints))
+		for i, x := range ints {
+			out[i] = int32(x)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// rationalsAs returns rats as the Go value of field type tp, if they all fit.
+func rationalsAs(tp tag.Type, rats [][2]int64) (any, bool) {
+	switch tp {
+	case tag.RATIONAL:
+		out := make([]tag.Rational, len(rats))
+		for i, r := range rats {
+			if r[0] < 0 || r[0] > math.MaxUint32 || r[1] < 0 || r[1] > math.MaxUint32 {
+				return nil, false
+			}
+			out[i] = tag.Rational{Num: uint32(r[0]), Den: uint32(r[1])}
+		}
+		return out, true
+	case tag.SRATIONAL:
+		out := make([]tag.SRational, len(rats))
+		for i, r := range rats {
+			if r[0] < math.MinInt32 || r[0] > math.MaxInt32 || r[1] < math.MinInt32 || r[1] > math.MaxInt32 {
+				return nil, false
+			}
+			out[i] = tag.SRational{Num: int32(r[0]), Den: int32(r[1])}
+		}
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,139 @@
+package exif
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"exif/pkg/tag"
+)
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		tag   tag.Tag
+		value any
+		tp    tag.Type
+		want  any
+		count uint64
+	}{
+		{tag.Make, "ACME", tag.ASCII, "ACME", 5},
+		{tag.Orientation, 6, tag.SHORT, []uint16{6}, 1},
+		{tag.ImageWidth, 100000, tag.LONG, []uint32{100000}, 1},
+		{tag.ImageWidth, uint8(200), tag.SHORT, []uint16{200}, 1},
+		{tag.ExposureTime, tag.Rational{Num: 1, Den: 250}, tag.RATIONAL, []tag.Rational{{Num: 1, Den: 250}}, 1},
+		{tag.ExposureTime, 0.004, tag.RATIONAL, []tag.Rational{{Num: 1, Den: 250}}, 1},
+		{tag.ExposureCompensation, [2]int64{-1, 3}, tag.SRATIONAL, []tag.SRational{{Num: -1, Den: 3}}, 1},
+		{tag.GPSLatitude, [][2]int64{{43, 1}, {28, 1}, {175, 100}}, tag.RATIONAL, []tag.Rational{{Num: 43, Den: 1}, {Num: 28, Den: 1}, {Num: 175, Den: 100}}, 3},
+		{tag.UserComment, []byte("ASCII\x00\x00\x00hi"), tag.UNDEFINED, []byte("ASCII\x00\x00\x00hi"), 10},
+		{tag.InteropIndex, "R98", tag.ASCII, "R98", 4},
+	}
+	for _, tt := range tests {
+		m := &Metadata{}
+		if err := m.Set(tt.tag, tt.value); err != nil {
+			t.Errorf("Set(%v, %#v): %v", tt.tag, tt.value, err)
+			continue
+		}
+		e, ok := m.Get(tt.tag)
+		if !ok || tag.Type(e.TypeID) != tt.tp || e.Count != tt.count || !reflect.DeepEqual(e.Value, tt.want) {
+			t.Errorf("Set(%v, %#v): got %s %d %#v, want %s %d %#v", tt.tag, tt.value,
+				tag.Type(e.TypeID), e.Count, e.Value, tt.tp, tt.count, tt.want)
+		}
+	}
+
+	// the Interoperability IFD is linked by the Exif IFD
+	m := &Metadata{}
+	if err := m.Set(tag.InteropIndex, "R98"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Interop == nil || m.Exif == nil {
+		t.Errorf("Interop %v, Exif %v", m.Interop, m.Exif)
+	}
+
+	// replacing a tag keeps its position and drops its duplicates
+	m = &Metadata{IFD0: &IFD{Namespace: tag.IFDTIFF, Entries: []IfdEntry{
+		{TagID: uint16(tag.Make), Value: "A", Index: 0},
+		{TagID: uint16(tag.Model), Value: "B", Index: 1},
+		{TagID: uint16(tag.Make), Value: "C", Index: 2},
+	}}}
+	if err := m.Set(tag.Make, "ACME"); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.IFD0.GetAll(uint16(tag.Make)); len(got) != 1 || got[0].Value != "ACME" || m.IFD0.Entries[0].TagID != uint16(tag.Make) {
+		t.Errorf("entries %+v", m.IFD0.Entries)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   tag.Tag
+		value any
+		want  error
+	}{
+		{"unknown tag", tag.Exif(0x1234), "x", ErrUnknownTag},
+		{"unknown GPS tag", tag.GPS(0x1234), "x", ErrUnknownTag},
+		{"Exif pointer", tag.ExifOffset, uint32(8), ErrManagedTag},
+		{"GPS pointer", tag.GPSInfo, uint32(8), ErrManagedTag},
+		{"Interop pointer", tag.InteropOffset, uint32(8), ErrManagedTag},
+		{"strip offsets", tag.StripOffsets, uint32(8), ErrManagedTag},
+		{"thumbnail offset", tag.ThumbnailOffset, uint32(8), ErrManagedTag},
+		{"one value expected", tag.Orientation, []uint16{1, 2}, ErrCount},
+		{"three values expected", tag.GPSLatitude, [][2]int64{{43, 1}}, ErrCount},
+		{"four bytes expected", tag.GPSVersionID, []byte{2, 3}, ErrCount},
+		{"string for a SHORT", tag.Orientation, "1", ErrValueType},
+		{"negative SHORT", tag.Orientation, -1, ErrValueType},
+		{"nil value", tag.Make, nil, ErrValueType},
+	}
+	for _, tt := range tests {
+		m := &Metadata{}
+		if err := m.Set(tt.tag, tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if m.IFD0 != nil || m.Exif != nil || m.GPS != nil {
+			t.Errorf("%s: IFD created", tt.name)
+		}
+	}
+
+	var m *Metadata
+	if err := m.Set(tag.Make, "ACME"); !errors.Is(err, ErrNilMetadata) {
+		t.Errorf("nil Metadata: got %v, want ErrNilMetadata", err)
+	}
+	if err := m.SetRational(tag.ExposureTime, 1, 250); !errors.Is(err, ErrNilMetadata) {
+		t.Errorf("nil Metadata: got %v, want ErrNilMetadata", err)
+	}
+	if err := m.SetGPS(&GPS{}); !errors.Is(err, ErrNilMetadata) {
+		t.Errorf("nil Metadata: got %v, want ErrNilMetadata", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m, err := DecodeFile("../../DSCN0012.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Delete(tag.Make) {
+		t.Error("Delete(Make) = false")
+	}
+	if _, ok := m.Get(tag.Make); ok {
+		t.Error("Make not deleted")
+	}
+	if m.Delete(tag.Make) {
+		t.Error("second Delete(Make) = true")
+	}
+
+	if !m.Delete(tag.GPSInfo) || m.GPS != nil {
+		t.Error("GPSInfo doesn't delete the GPS IFD")
+	}
+	if !m.Delete(tag.ExifOffset) || m.Exif != nil || m.Interop != nil {
+		t.Error("ExifOffset doesn't delete the Exif and Interop IFDs")
+	}
+	if m.Delete(tag.ExposureTime) || m.Delete(tag.InteropOffset) {
+		t.Error("deleted a tag of a removed IFD")
+	}
+
+	var nilMeta *Metadata
+	if nilMeta.Delete(tag.Make) {
+		t.Error("Delete on a nil Metadata = true")
+	}
+}
@@ -0,0 +1,416 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// maxAPP1Payload is the largest APP1 payload: the 16 bits segment
+// length counts itself.
+const maxAPP1Payload = math.MaxUint16 - 2
+
+// tags written by the encoder from the IFD tree rather than copied
+// from the entries: IFD pointers and offsets/lengths of the image data
+var (
+	// pointers to sub-IFDs
+	pointerTags = map[tag.IFD]map[uint16]bool{
+		tag.IFDTIFF: {uint16(tag.ExifOffset): true, uint16(tag.GPSInfo): true},
+		tag.IFDExif: {uint16(tag.InteropOffset): true},
+	}
+
+	// image data of TIFF files (not carried by APP1), thumbnail data, and
+	// SubIFD, whose child IFDs aren't part of the tree
+	dataTags = map[uint16]bool{
+		uint16(tag.StripOffsets):    true,
+		uint16(tag.StripByteCounts): true,
+		uint16(tag.TileOffsets):     true,
+		uint16(tag.TileByteCounts):  true,
+		uint16(tag.ThumbnailOffset): true,
+		uint16(tag.ThumbnailLength): true,
+		uint16(tag.SubIFD):          true,
+	}
+)
+
+// encEntry is an entry ready to be laid out.
+type encEntry struct {
+	tag   uint16
+	tp    tag.Type
+	count uint32
+	data  []byte // count*TypeSizes[tp] bytes
+
+	// the value is the offset of link, or of the thumbnail data
+	link  *encIFD
+	thumb bool
+
+	// the data is kept at this offset rather than after the entry
+	// table, see pinnedOffset
+	pinned bool
+}
+
+// encIFD is an IFD ready to be laid out: its entry table, then
+// the out-of-line values.
+type encIFD struct {
+	entries []encEntry
+	offset  uint32
+	next    *encIFD
+}
+
+// size returns the number of bytes of the IFD, values included,
+// padded to a word boundary.
+func (d *encIFD) size() uint32 {
+	n := uint32(2 + 12*len(d.entries) + 4)
+	for _, e := range d.entries {
+		if len(e.data) > 4 && !e.pinned {
+			n += uint32(len(e.data)+1) &^ 1
+		}
+	}
+	return n
+}
+
+// EncodeAPP1 serializes the IFD tree of m (IFD0, Exif, GPS, Interop, and IFD1
+// with its thumbnail) into the payload of an Exif APP1 segment, "Exif\0\0"
+// followed by the TIFF data. See EncodeTIFF.
+func (m *Metadata) EncodeAPP1() ([]byte, error) {
+	tiff, err := m.EncodeTIFF()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := append([]byte(SignatureExif), tiff...)
+	if len(payload) > maxAPP1Payload {
+		return nil, errors.Wrapf(ErrSegmentTooLarge, "APP1 payload of %d bytes, the limit is %d", len(payload), maxAPP1Payload)
+	}
+	return payload, nil
+}
+
+// EncodeTIFF serializes the IFD tree of m into classic TIFF data in the m.ByteOrder
+// byte order (little-endian when unset). The entries of each IFD are written
+// sorted by tag, their values are re-encoded from IfdEntry.Value and TypeID,
+// the counts are recomputed and out-of-line values are word-aligned.
+//
+// Pointers to the sub-IFDs and to the thumbnail are written from the tree,
+// the IFDs of Chain past IFD1, the image data of TIFF files and the SubIFD
+// tag are not written. BigTIFF LONG8, SLONG8 and IFD8 values are written
+// as LONG and SLONG when they fit in 32 bits. Entries of unknown types
+// can't be re-encoded and make EncodeTIFF fail with ErrUnknownType.
+//
+// The offsets in many maker notes are relative to the TIFF header, so an
+// unchanged MakerNote is kept at its offset in the data m was decoded
+// from, the other values being laid out around it.
+func (m *Metadata) EncodeTIFF() ([]byte, error) {
+	endian := m.ByteOrder
+	if endian.ByteOrder() == nil {
+		endian = LittleEndian
+	}
+	bo := endian.ByteOrder().(interface {
+		binary.ByteOrder
+		binary.AppendByteOrder
+	})
+
+	var thumb *Thumbnail
+	if m.IFD1 != nil {
+		var err error
+		if thumb, err = m.Thumbnail(); err != nil && !errors.Is(err, ErrNoThumbnail) {
+			return nil, errors.Wrap(err, "failed to read thumbnail")
+		}
+	}
+
+	// data of the MakerNote kept at its offset
+	var pinned []byte
+	var pinnedOffset uint32
+
+	prepare := func(ifd *IFD, ns tag.IFD, path string) (*encIFD, error) {
+		if ifd == nil {
+			return nil, nil
+		}
+		enc := &encIFD{}
+		for _, e := range ifd.Entries {
+			if pointerTags[ns][e.TagID] || (ns == tag.IFDTIFF && dataTags[e.TagID]) {
+				continue
+			}
+			if ifd == m.IFD1 && thumb != nil && thumb.Compression == tag.CompressionNone && e.TagID == uint16(tag.RowsPerStrip) {
+				// the strips are written as one
+				continue
+			}
+
+			tp, data, err := encodeValue(bo, tag.Type(e.TypeID), e.Value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s: tag 0x%04X", path, e.TagID)
+			}
+			enc.entries = append(enc.entries, encEntry{
+				tag:   e.TagID,
+				tp:    tp,
+				count: uint32(len(data) / int(tag.TypeSizes[tp])),
+				data:  data,
+			})
+			if ns == tag.IFDExif && e.TagID == uint16(tag.MakerNote) && pinned == nil {
+				if off, ok := m.pinnedOffset(e, data); ok {
+					enc.entries[len(enc.entries)-1].pinned = true
+					pinned, pinnedOffset = data, off
+				}
+			}
+		}
+		if len(enc.entries) == 0 && ns != tag.IFDTIFF {
+			// sub-IFDs left empty, e.g. by Delete, aren't linked
+			return nil, nil
+		}
+		return enc, nil
+	}
+
+	ifd0, err := prepare(m.IFD0, tag.IFDTIFF, "IFD0")
+	if err != nil {
+		return nil, err
+	}
+	if ifd0 == nil {
+		ifd0 = &encIFD{}
+	}
+	exif, err := prepare(m.Exif, tag.IFDExif, "IFD0/Exif")
+	if err != nil {
+		return nil, err
+	}
+	gps, err := prepare(m.GPS, tag.IFDGPS, "IFD0/GPS")
+	if err != nil {
+		return nil, err
+	}
+	interop, err := prepare(m.Interop, tag.IFDInterop, "IFD0/Exif/Interop")
+	if err != nil {
+		return nil, err
+	}
+	ifd1, err := prepare(m.IFD1, tag.IFDTIFF, "IFD1")
+	if err != nil {
+		return nil, err
+	}
+
+	// the Interoperability IFD hangs off the Exif sub-IFD, so one is
+	// created when there is none
+	if interop != nil && exif == nil {
+		exif = &encIFD{}
+	}
+
+	link := func(parent *encIFD, id uint16, child *encIFD) {
+		if parent != nil && child != nil {
+			parent.entries = append(parent.entries, encEntry{tag: id, tp: tag.LONG, count: 1, data: make([]byte, 4), link: child})
+		}
+	}
+	link(ifd0, uint16(tag.ExifOffset), exif)
+	link(ifd0, uint16(tag.GPSInfo), gps)
+	link(exif, uint16(tag.InteropOffset), interop)
+	ifd0.next = ifd1
+
+	var thumbData []byte
+	if ifd1 != nil && thumb != nil {
+		thumbData = thumb.Data
+		length := bo.AppendUint32(nil, uint32(len(thumbData)))
+		if thumb.Compression == tag.CompressionNone {
+			ifd1.entries = append(ifd1.entries,
+				encEntry{tag: uint16(tag.StripOffsets), tp: tag.LONG, count: 1, data: make([]byte, 4), thumb: true},
+				encEntry{tag: uint16(tag.RowsPerStrip), tp: tag.LONG, count: 1, data: bo.AppendUint32(nil, uint32(thumb.Height))},
+				encEntry{tag: uint16(tag.StripByteCounts), tp: tag.LONG, count: 1, data: length},
+			)
+		} else {
+			ifd1.entries = append(ifd1.entries,
+				encEntry{tag: uint16(tag.ThumbnailOffset), tp: tag.LONG, count: 1, data: make([]byte, 4), thumb: true},
+				encEntry{tag: uint16(tag.ThumbnailLength), tp: tag.LONG, count: 1, data: length},
+			)
+		}
+	}
+
+	// lay out the header, the IFDs each followed by its values,
+	// then the thumbnail, skipping over the pinned MakerNote
+	var ifds []*encIFD
+	for _, d := range []*encIFD{ifd0, exif, interop, gps, ifd1} {
+		if d != nil {
+			ifds = append(ifds, d)
+		}
+	}
+	pinnedEnd := (uint64(pinnedOffset) + uint64(len(pinned)) + 1) &^ 1
+	size := uint64(8)
+	place := func(n uint64) uint32 {
+		if pinned != nil && size < pinnedEnd && size+n > uint64(pinnedOffset) {
+			size = pinnedEnd
+		}
+		off := uint32(size)
+		size += n
+		return off
+	}
+	for _, d := range ifds {
+		d.offset = place(uint64(d.size()))
+	}
+	thumbOffset := place(uint64(len(thumbData)))
+	if pinned != nil && size < pinnedEnd {
+		size = pinnedEnd
+	}
+	if size > math.MaxUint32 {
+		return nil, errors.Wrapf(ErrSegmentTooLarge, "TIFF data of %d bytes", size)
+	}
+
+	out := make([]byte, 0, size)
+	if endian == BigEndian {
+		out = append(out, tiffSignatureMM...)
+	} else {
+		out = append(out, tiffSignatureII...)
+	}
+	out = bo.AppendUint32(out, ifd0.offset)
+
+	// writeAt pads out with zeros up to off, then appends data
+	writeAt := func(off uint32, data []byte) {
+		if pinned != nil && len(out) <= int(pinnedOffset) && int(pinnedOffset) < int(off) {
+			out = append(out, make([]byte, int(pinnedOffset)-len(out))...)
+			out = append(out, pinned...)
+		}
+		out = append(out, make([]byte, int(off)-len(out))...)
+		out = append(out, data...)
+	}
+
+	for _, d := range ifds {
+		sort.SliceStable(d.entries, func(i, j int) bool { return d.entries[i].tag < d.entries[j].tag })
+
+		values := d.offset + uint32(2+12*len(d.entries)+4)
+		var table, area []byte
+		table = bo.AppendUint16(table, uint16(len(d.entries)))
+		for _, e := range d.entries {
+			switch {
+			case e.link != nil:
+				bo.PutUint32(e.data, e.link.offset)
+			case e.thumb:
+				bo.PutUint32(e.data, thumbOffset)
+			}
+
+			table = bo.AppendUint16(table, e.tag)
+			table = bo.AppendUint16(table, uint16(e.tp))
+			table = bo.AppendUint32(table, e.count)
+			switch {
+			case e.pinned:
+				table = bo.AppendUint32(table, pinnedOffset)
+			case len(e.data) > 4:
+				table = bo.AppendUint32(table, values+uint32(len(area)))
+				area = append(area, e.data...)
+				if len(area)%2 != 0 {
+					area = append(area, 0)
+				}
+			default:
+				var inline [4]byte
+				copy(inline[:], e.data)
+				table = append(table, inline[:]...)
+			}
+		}
+		var next uint32
+		if d.next != nil {
+			next = d.next.offset
+		}
+		table = bo.AppendUint32(table, next)
+		writeAt(d.offset, append(table, area...))
+	}
+	writeAt(thumbOffset, thumbData)
+	writeAt(uint32(size), nil)
+	return out, nil
+}
+
+// pinnedOffset returns the offset of the value of e in the TIFF data m was
+// decoded from, when it's out-of-line, fits in classic TIFF and still holds
+// data.
+func (m *Metadata) pinnedOffset(e IfdEntry, data []byte) (uint32, bool) {
+	t := m.tiff
+	if t == nil {
+		return 0, false
+	}
+	field, size := e.Offset+8, uint64(4)
+	if t.big {
+		field, size = e.Offset+12, 8
+	}
+	if uint64(len(data)) <= size || !t.fits(field, size) {
+		return 0, false
+	}
+	raw, err := t.bytes(field, size)
+	if err != nil {
+		return 0, false
+	}
+	var off uint64
+	if t.big {
+		off = t.bo.Uint64(raw)
+	} else {
+		off = uint64(t.bo.Uint32(raw))
+	}
+	if off < 8 || off+uint64(len(data)) > math.MaxUint32 || !t.fits(off, uint64(len(data))) {
+		return 0, false
+	}
+	if raw, err = t.bytes(off, uint64(len(data))); err != nil || !bytes.Equal(raw, data) {
+		return 0, false
+	}
+	return uint32(off), true
+}
+
+// encodeValue is the inverse of decodeValue: it returns the raw bytes of v,
+// the value of a field of type tp. BigTIFF types are narrowed to their
+// 32 bits counterparts, which is the returned type.
+func encodeValue(bo binary.ByteOrder, tp tag.Type, v any) (tag.Type, []byte, error) {
+	ok := false
+	switch tp {
+	case tag.ASCII:
+		if s, isString := v.(string); isString {
+			// NUL terminated
+			return tp, append([]byte(s), 0), nil
+		}
+	case tag.BYTE, tag.UNDEFINED:
+		if b, isBytes := v.([]byte); isBytes {
+			return tp, b, nil
+		}
+	case tag.SHORT:
+		_, ok = v.([]uint16)
+	case tag.LONG, tag.IFD4:
+		_, ok = v.([]uint32)
+	case tag.RATIONAL:
+		_, ok = v.([]tag.Rational)
+	case tag.SBYTE:
+		_, ok = v.([]int8)
+	case tag.SSHORT:
+		_, ok = v.([]int16)
+	case tag.SLONG:
+		_, ok = v.([]int32)
+	case tag.SRATIONAL:
+		_, ok = v.([]tag.SRational)
+	case tag.FLOAT:
+		_, ok = v.([]float32)
+	case tag.DOUBLE:
+		_, ok = v.([]float64)
+	case tag.LONG8, tag.IFD8:
+		if u, isUint64s := v.([]uint64); isUint64s {
+			narrow := make([]uint32, len(u))
+			for i, x := range u {
+				if x > math.MaxUint32 {
+					return 0, nil, errors.Wrapf(ErrValueType, "LONG8 value %d overflows LONG", x)
+				}
+				narrow[i] = uint32(x)
+			}
+			tp, v, ok = tag.LONG, narrow, true
+		}
+	case tag.SLONG8:
+		if s, isInt64s := v.([]int64); isInt64s {
+			narrow := make([]int32, len(s))
+			for i, x := range s {
+				if x < math.MinInt32 || x > math.MaxInt32 {
+					return 0, nil, errors.Wrapf(ErrValueType, "SLONG8 value %d overflows SLONG", x)
+				}
+				narrow[i] = int32(x)
+			}
+			tp, v, ok = tag.SLONG, narrow, true
+		}
+	default:
+		return 0, nil, errors.Wrapf(ErrUnknownType, "%s", tp)
+	}
+	if !ok {
+		return 0, nil, errors.Wrapf(ErrValueType, "%T value for a %s field", v, tp)
+	}
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, bo, v); err != nil {
+		return 0, nil, errors.Wrap(err, "failed to encode value")
+	}
+	return tp, buf.Bytes(), nil
+}
@@ -0,0 +1,323 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"exif/pkg/tag"
+)
+
+func TestEncodeTIFFRoundTrip(t *testing.T) {
+	values := []struct {
+		tag   tag.Tag
+		value any
+	}{
+		{tag.Make, "ACME"},
+		{tag.Orientation, uint16(6)},
+		{tag.ImageWidth, uint32(100000)},
+		{tag.XResolution, tag.Rational{Num: 300, Den: 1}},
+		{tag.TimeZoneOffset, []int16{-5, 1}},
+		{tag.ExposureTime, tag.Rational{Num: 1, Den: 250}},
+		{tag.ExposureCompensation, tag.SRational{Num: -2, Den: 3}},
+		{tag.UserComment, []byte("ASCII\x00\x00\x00hello")},
+		{tag.GPSLatitude, []tag.Rational{{Num: 43, Den: 1}, {Num: 28, Den: 1}, {Num: 175, Den: 100}}},
+		{tag.InteropIndex, "R98"},
+	}
+
+	for _, order := range []EndianType{LittleEndian, BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			m := &Metadata{ByteOrder: order}
+			for _, v := range values {
+				if err := m.Set(v.tag, v.value); err != nil {
+					t.Fatalf("Set(%v): %v", v.tag, err)
+				}
+			}
+
+			data, err := m.EncodeTIFF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(data[:2]), map[EndianType]string{LittleEndian: "II", BigEndian: "MM"}[order]; got != want {
+				t.Errorf("byte-order mark %q, want %q", got, want)
+			}
+
+			got, err := ParseTIFF(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ByteOrder != order {
+				t.Errorf("ByteOrder %v, want %v", got.ByteOrder, order)
+			}
+			for _, v := range values {
+				want, _ := m.Get(v.tag)
+				e, ok := got.Get(v.tag)
+				if !ok {
+					t.Errorf("%v: missing", v.tag)
+					continue
+				}
+				if e.TypeID != want.TypeID || e.Count != want.Count || !reflect.DeepEqual(e.Value, want.Value) {
+					t.Errorf("%v: got %s %d %#v, want %s %d %#v", v.tag,
+						tag.Type(e.TypeID), e.Count, e.Value, tag.Type(want.TypeID), want.Count, want.Value)
+				}
+			}
+		})
+	}
+}
+
+// TestEncodeTIFFFile re-encodes the metadata of a camera file, every entry
+// but the pointers being written back as decoded.
+func TestEncodeTIFFFile(t *testing.T) {
+	for _, path := range []string{"../../DSCN0012.jpg", "../../fujifilm-dx10.jpg"} {
+		t.Run(path, func(t *testing.T) {
+			m, err := DecodeFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := m.EncodeTIFF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseTIFF(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, ifds := range [][2]*IFD{{m.IFD0, got.IFD0}, {m.Exif, got.Exif}, {m.GPS, got.GPS}, {m.Interop, got.Interop}, {m.IFD1, got.IFD1}} {
+				want, have := ifds[0], ifds[1]
+				if want == nil {
+					continue
+				}
+				if have == nil {
+					t.Errorf("%v IFD missing", want.Namespace)
+					continue
+				}
+				for _, e := range want.Entries {
+					if pointerTags[want.Namespace][e.TagID] || (want.Namespace == tag.IFDTIFF && dataTags[e.TagID]) {
+						continue
+					}
+					g, ok := have.Get(e.TagID)
+					if !ok {
+						t.Errorf("%s missing", e.Name)
+						continue
+					}
+					if g.TypeID != e.TypeID || !reflect.DeepEqual(g.Value, e.Value) {
+						t.Errorf("%s: got %d %#v, want %d %#v", e.Name, g.TypeID, g.Value, e.TypeID, e.Value)
+					}
+				}
+			}
+		})
+	}
+}
+
+// bigEntry is an entry of a BigTIFF IFD, its value in-line.
+type bigEntry struct {
+	tag, tp uint16
+	count   uint64
+	value   []byte
+}
+
+// bigTIFF returns a BigTIFF file with IFD0 and, when exif isn't empty,
+// an Exif sub-IFD pointed to by an IFD8 ExifOffset.
+func bigTIFF(order EndianType, ifd0, exif []bigEntry) []byte {
+	bo := order.ByteOrder().(binary.AppendByteOrder)
+	ifdSize := func(n int) uint64 { return 8 + uint64(n)*20 + 8 }
+
+	if len(exif) > 0 {
+		off := 16 + ifdSize(len(ifd0)+1)
+		ifd0 = append(ifd0, bigEntry{uint16(tag.ExifOffset), uint16(tag.IFD8), 1, bo.AppendUint64(nil, off)})
+	}
+
+	data := []byte(map[EndianType]string{LittleEndian: "II", BigEndian: "MM"}[order])
+	data = bo.AppendUint16(data, bigTIFFMagic)
+	data = bo.AppendUint16(data, 8)
+	data = bo.AppendUint16(data, 0)
+	data = bo.AppendUint64(data, 16)
+	for _, entries := range [][]bigEntry{ifd0, exif} {
+		if len(entries) == 0 {
+			continue
+		}
+		data = bo.AppendUint64(data, uint64(len(entries)))
+		for _, e := range entries {
+			data = bo.AppendUint16(data, e.tag)
+			data = bo.AppendUint16(data, e.tp)
+			data = bo.AppendUint64(data, e.count)
+			var value [8]byte
+			copy(value[:], e.value)
+			data = append(data, value[:]...)
+		}
+		data = bo.AppendUint64(data, 0)
+	}
+	return data
+}
+
+func TestEncodeTIFFFromBigTIFF(t *testing.T) {
+	for _, order := range []EndianType{LittleEndian, BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			bo := order.ByteOrder().(binary.AppendByteOrder)
+			data := bigTIFF(order,
+				[]bigEntry{
+					{uint16(tag.ImageWidth), uint16(tag.LONG8), 1, bo.AppendUint64(nil, 640)},
+					{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")},
+					{uint16(tag.Orientation), uint16(tag.SHORT), 1, bo.AppendUint16(nil, 1)},
+					{uint16(tag.TimeZoneOffset), uint16(tag.SLONG8), 1, bo.AppendUint64(nil, uint64(1<<64-2))},
+				},
+				[]bigEntry{
+					{uint16(tag.ExposureTime), uint16(tag.RATIONAL), 1, bo.AppendUint32(bo.AppendUint32(nil, 1), 250)},
+				})
+
+			m, err := ParseTIFF(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e, _ := m.Get(tag.ImageWidth); !reflect.DeepEqual(e.Value, []uint64{640}) {
+				t.Fatalf("BigTIFF ImageWidth %#v", e.Value)
+			}
+
+			enc, err := m.EncodeTIFF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if magic := order.ByteOrder().Uint16(enc[2:4]); magic != tiffMagic {
+				t.Fatalf("magic %d, want %d", magic, tiffMagic)
+			}
+			got, err := ParseTIFF(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// LONG8 and SLONG8 are narrowed to LONG and SLONG
+			for _, tt := range []struct {
+				tag   tag.Tag
+				tp    tag.Type
+				value any
+			}{
+				{tag.ImageWidth, tag.LONG, []uint32{640}},
+				{tag.Make, tag.ASCII, "ACME"},
+				{tag.Orientation, tag.SHORT, []uint16{1}},
+				{tag.TimeZoneOffset, tag.SLONG, []int32{-2}},
+				{tag.ExposureTime, tag.RATIONAL, []tag.Rational{{Num: 1, Den: 250}}},
+			} {
+				e, ok := got.Get(tt.tag)
+				if !ok {
+					t.Errorf("%v: missing", tt.tag)
+					continue
+				}
+				if tag.Type(e.TypeID) != tt.tp || !reflect.DeepEqual(e.Value, tt.value) {
+					t.Errorf("%v: got %s %#v, want %s %#v", tt.tag, tag.Type(e.TypeID), e.Value, tt.tp, tt.value)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeTIFFLong8Overflow(t *testing.T) {
+	bo := binary.LittleEndian
+	data := bigTIFF(LittleEndian, []bigEntry{
+		{uint16(tag.ImageWidth), uint16(tag.LONG8), 1, bo.AppendUint64(nil, 1<<32)},
+	}, nil)
+	m, err := ParseTIFF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.EncodeTIFF(); !errors.Is(err, ErrValueType) {
+		t.Errorf("got %v, want ErrValueType", err)
+	}
+}
+
+// TestEncodeTIFFMakerNote checks the MakerNote stays at its offset when the
+// IFDs before it grow or shrink.
+func TestEncodeTIFFMakerNote(t *testing.T) {
+	m, err := DecodeFile("../../DSCN0012.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	note, _ := m.Get(tag.MakerNote)
+	want, ok := m.pinnedOffset(note, note.Value.([]byte))
+	if !ok {
+		t.Fatal("MakerNote offset not found")
+	}
+
+	for _, artist := range []string{"", "A", strings.Repeat("A", 5000)} {
+		if artist == "" {
+			m.Delete(tag.Make)
+		} else if err := m.Set(tag.Artist, artist); err != nil {
+			t.Fatal(err)
+		}
+		data, err := m.EncodeTIFF()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseTIFF(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, _ := got.Get(tag.MakerNote)
+		if !reflect.DeepEqual(e.Value, note.Value) {
+			t.Fatalf("Artist %d bytes: MakerNote changed", len(artist))
+		}
+		if off, _ := got.pinnedOffset(e, note.Value.([]byte)); off != want {
+			t.Errorf("Artist %d bytes: MakerNote at %d, want %d", len(artist), off, want)
+		}
+		if _, err := got.Thumbnail(); err != nil {
+			t.Errorf("Artist %d bytes: %v", len(artist), err)
+		}
+	}
+
+	// a new value is laid out with the others
+	if err := m.Set(tag.MakerNote, []byte("new maker note")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.EncodeTIFF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseTIFF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := got.Get(tag.MakerNote)
+	if off, _ := got.pinnedOffset(e, []byte("new maker note")); off == want {
+		t.Errorf("new MakerNote kept at %d", want)
+	}
+}
+
+func TestEncodeTIFFUnknownType(t *testing.T) {
+	m, err := ParseTIFF(bigTIFF(LittleEndian, []bigEntry{
+		{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")},
+		{uint16(tag.Model), 99, 1, []byte{1, 2, 3, 4}},
+	}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.EncodeTIFF(); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("got %v, want ErrUnknownType", err)
+	}
+}
+
+func TestEncodeTIFFSubIFD(t *testing.T) {
+	bo := binary.LittleEndian
+	m, err := ParseTIFF(bigTIFF(LittleEndian, []bigEntry{
+		{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")},
+		{uint16(tag.SubIFD), uint16(tag.IFD8), 1, bo.AppendUint64(nil, 16)},
+	}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.EncodeTIFF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseTIFF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := got.Get(tag.SubIFD); ok {
+		t.Errorf("SubIFD written: %v", e.Value)
+	}
+	if err := m.Set(tag.SubIFD, uint32(8)); !errors.Is(err, ErrManagedTag) {
+		t.Errorf("Set(SubIFD): got %v, want ErrManagedTag", err)
+	}
+}
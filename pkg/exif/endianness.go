@@ -1,4 +1,4 @@
-package main
+package exif
 
 import "encoding/binary"
 
@@ -0,0 +1,126 @@
+package exif
+
+import (
+	"math"
+
+	"exif/pkg/tag"
+)
+
+// The accessors below return the value of an enumerated tag as its type of
+// package tag. They return false when the tag is absent or isn't a single
+// integer; the value isn't validated, use its IsValid method for that.
+
+func (m *Metadata) Orientation() (tag.OrientationValue, bool) {
+	return enumValue[tag.OrientationValue](m, tag.Orientation)
+}
+
+func (m *Metadata) ResolutionUnit() (tag.ResolutionUnitValue, bool) {
+	return enumValue[tag.ResolutionUnitValue](m, tag.ResolutionUnit)
+}
+
+func (m *Metadata) FocalPlaneResolutionUnit() (tag.ResolutionUnitValue, bool) {
+	return enumValue[tag.ResolutionUnitValue](m, tag.FocalPlaneResolutionUnit)
+}
+
+func (m *Metadata) YCbCrPositioning() (tag.YCbCrPositioningValue, bool) {
+	return enumValue[tag.YCbCrPositioningValue](m, tag.YCbCrPositioning)
+}
+
+func (m *Metadata) Compression() (tag.CompressionValue, bool) {
+	return enumValue[tag.CompressionValue](m, tag.Compression)
+}
+
+func (m *Metadata) PhotometricInterpretation() (tag.PhotometricInterpretationValue, bool) {
+	return enumValue[tag.PhotometricInterpretationValue](m, tag.PhotometricInterpretation)
+}
+
+func (m *Metadata) PlanarConfiguration() (tag.PlanarConfigurationValue, bool) {
+	return enumValue[tag.PlanarConfigurationValue](m, tag.PlanarConfiguration)
+}
+
+func (m *Metadata) ExposureProgram() (tag.ExposureProgramValue, bool) {
+	return enumValue[tag.ExposureProgramValue](m, tag.ExposureProgram)
+}
+
+func (m *Metadata) MeteringMode() (tag.MeteringModeValue, bool) {
+	return enumValue[tag.MeteringModeValue](m, tag.MeteringMode)
+}
+
+func (m *Metadata) LightSource() (tag.LightSourceValue, bool) {
+	return enumValue[tag.LightSourceValue](m, tag.LightSource)
+}
+
+func (m *Metadata) Flash() (tag.FlashValue, bool) {
+	return enumValue[tag.FlashValue](m, tag.Flash)
+}
+
+func (m *Metadata) SensingMethod() (tag.SensingMethodValue, bool) {
+	return enumValue[tag.SensingMethodValue](m, tag.SensingMethod)
+}
+
+func (m *Metadata) ColorSpace() (tag.ColorSpaceValue, bool) {
+	return enumValue[tag.ColorSpaceValue](m, tag.ColorSpace)
+}
+
+func (m *Metadata) CustomRendered() (tag.CustomRenderedValue, bool) {
+	return enumValue[tag.CustomRenderedValue](m, tag.CustomRendered)
+}
+
+func (m *Metadata) ExposureMode() (tag.ExposureModeValue, bool) {
+	return enumValue[tag.ExposureModeValue](m, tag.ExposureMode)
+}
+
+func (m *Metadata) WhiteBalance() (tag.WhiteBalanceValue, bool) {
+	return enumValue[tag.WhiteBalanceValue](m, tag.WhiteBalance)
+}
+
+func (m *Metadata) SceneCaptureType() (tag.SceneCaptureTypeValue, bool) {
+	return enumValue[tag.SceneCaptureTypeValue](m, tag.SceneCaptureType)
+}
+
+func (m *Metadata) GainControl() (tag.GainControlValue, bool) {
+	return enumValue[tag.GainControlValue](m, tag.GainControl)
+}
+
+func (m *Metadata) Contrast() (tag.ContrastValue, bool) {
+	return enumValue[tag.ContrastValue](m, tag.Contrast)
+}
+
+func (m *Metadata) Saturation() (tag.SaturationValue, bool) {
+	return enumValue[tag.SaturationValue](m, tag.Saturation)
+}
+
+func (m *Metadata) Sharpness() (tag.SharpnessValue, bool) {
+	return enumValue[tag.SharpnessValue](m, tag.Sharpness)
+}
+
+func (m *Metadata) SubjectDistanceRange() (tag.SubjectDistanceRangeValue, bool) {
+	return enumValue[tag.SubjectDistanceRangeValue](m, tag.SubjectDistanceRange)
+}
+
+func (m *Metadata) FileSource() (tag.FileSourceValue, bool) {
+	return enumValue[tag.FileSourceValue](m, tag.FileSource)
+}
+
+func (m *Metadata) SceneType() (tag.SceneTypeValue, bool) {
+	return enumValue[tag.SceneTypeValue](m, tag.SceneType)
+}
+
+// enumValue returns the single value of tag t: a SHORT or LONG, or a BYTE or
+// UNDEFINED for FileSource and SceneType.
+func enumValue[T ~uint16](m *Metadata, t tag.Tag) (T, bool) {
+	e, ok := m.Get(t)
+	if !ok {
+		return 0, false
+	}
+	if b, ok := e.Value.([]byte); ok {
+		if len(b) != 1 {
+			return 0, false
+		}
+		return T(b[0]), true
+	}
+	if u := uints(e.Value); len(u) == 1 && u[0] <= math.MaxUint16 {
+		return T(u[0]), true
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+package exif
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors wrapped by FormatError. Use errors.Is to test for them.
+var (
+	// data ends before a count, entry, value or pointer could be read
+	ErrTruncated = errors.New("truncated data")
+
+	// an offset points outside of the TIFF data
+	ErrOffsetOutOfRange = errors.New("offset out of range")
+
+	// the TIFF header doesn't start with 'II' or 'MM'
+	ErrByteOrder = errors.New("bad byte-order mark")
+
+	// the TIFF header magic number is neither 42 (TIFF) nor 43 (BigTIFF)
+	ErrTIFFMagic = errors.New("bad TIFF magic")
+
+	// a value of a field type that's not part of TIFF 6.0 or BigTIFF can't be encoded
+	ErrUnknownType = errors.New("unknown field type")
+
+	// a sub-IFD pointer tag (ExifOffset, GPSInfo, ...) doesn't hold a LONG or LONG8 offset
+	ErrInvalidPointer = errors.New("invalid IFD pointer")
+
+	// the next pointer of an IFD points back to an IFD of the chain
+	ErrIFDLoop = errors.New("IFD loop")
+
+	// the stream doesn't start with a JPEG SOI marker
+	ErrNotJPEG = errors.New("not a JPEG stream")
+
+	// an APP0 segment is neither JFIF nor JFXX
+	ErrSignature = errors.New("unexpected segment signature")
+
+	// a JFXX APP0 segment uses an unknown thumbnail extension code
+	ErrExtensionCode = errors.New("unknown JFXX extension code")
+)
+
+// ErrNoThumbnail is returned when an image doesn't embed a thumbnail.
+var ErrNoThumbnail = errors.New("no thumbnail")
+
+// Errors returned when encoding metadata.
+var (
+	// an entry value doesn't have the Go type decoded for its field type
+	ErrValueType = errors.New("value doesn't match field type")
+
+	// the encoded data doesn't fit in its segment
+	ErrSegmentTooLarge = errors.New("segment too large")
+)
+
+// FormatError reports malformed Exif data, along with where it was found.
+type FormatError struct {
+	// one of the sentinel errors above
+	Err error
+
+	// byte offset of the problem, relative to the start of the TIFF header
+	// (or of the segment, for JPEG segments other than Exif APP1, or of
+	// the stream, for the JPEG segment framing)
+	Offset int64
+
+	// IFD being walked when the problem was found, e.g. "IFD0/GPS";
+	// empty for header errors
+	IFD string
+
+	// extra detail about the problem
+	Msg string
+}
+
+func (e *FormatError) Error() string {
+	s := "exif: "
+	if e.IFD != "" {
+		s += e.IFD + ": "
+	}
+	s += fmt.Sprintf("%s at offset %d", e.Err, e.Offset)
+	if e.Msg != "" {
+		s += ": " + e.Msg
+	}
+	return s
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// formatErr builds a *FormatError, offset is relative to the TIFF header.
+func formatErr(err error, ifd string, offset uint64, format string, args ...any) error {
+	return &FormatError{
+		Err:    err,
+		Offset: int64(offset),
+		IFD:    ifd,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
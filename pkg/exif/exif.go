@@ -1,4 +1,11 @@
-package main
+package exif
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
 // EXIF APP1 segment
 // EXIF (Exchangable Image File Format) JPEG file use APP1 segments
@@ -40,3 +47,50 @@ type APP1 struct {
 	// ("Exif\000\000" = 0x457869660000), not stored
 	Identifier [6]byte `json:"identifier"`
 }
+
+// FFE1 [2] -> APP1 Marker
+// SSSS [2] -> APP1 Data Size (bytes). NOTE the size `SSSS` includes the size of descriptor as well
+// 45 78 69 66 00 00 [6] -> Exif Header. this is a special data to identify whether EXIF or not, ASCII chars "Exif" and 2 bytes of 0x00 used. After the APP1 Marker area, the other JPEG Markers follows.
+// 4949 2A00 0800 0000  [8] -> TIFF Header. NOTE if tiff header this is
+
+// Roughly structure of Exif data (APP1) is shown as below.
+// This is a case of "Intel" byte align and it contains JPEG format thumbnail.
+// As described above, Exif data is starts from ASCII character "Exif" and 2bytes of 0x00,
+// then Exif data follows. Exif uses TIFF format to store data.
+// For more datails of TIFF format, please refer to "TIFF6.0 specification".
+
+const (
+	// marker and size of a segment
+	segmentHeaderLen = 4
+
+	// "Exif\x00\x00" identifier of the APP1 segment
+	exifHeaderLen = len(SignatureExif)
+)
+
+// ParseAPP1 parses an APP1 segment (starting with its 0xFFE1 marker)
+// and returns the entries of every IFD it contains. It returns ErrNoExif
+// when the segment isn't an Exif APP1 segment, e.g. an XMP one.
+func ParseAPP1(v []byte) (*Metadata, error) {
+	// marker, size, exif header and the 8 bytes TIFF header
+	if len(v) < segmentHeaderLen+exifHeaderLen+8 {
+		return nil, &FormatError{Err: ErrTruncated, Msg: fmt.Sprintf("APP1 segment of %d bytes is too short for a TIFF header", len(v))}
+	}
+
+	// APP1 Marker
+	if !bytes.Equal(v[:2], []byte{markerPrefix, byte(MarkerAPP1)}) {
+		return nil, errors.Wrapf(ErrNoExif, "marker 0x%02X%02X", v[0], v[1])
+	}
+
+	// APP1 Data Size skipped
+	if id := v[segmentHeaderLen : segmentHeaderLen+exifHeaderLen]; string(id) != SignatureExif {
+		return nil, errors.Wrapf(ErrNoExif, "identifier %q", id)
+	}
+
+	tiff := v[segmentHeaderLen+exifHeaderLen:]
+	endian, ok := EndianTypeFromStr[string(tiff[:2])]
+	if !ok {
+		return nil, &FormatError{Err: ErrByteOrder, Msg: fmt.Sprintf("got %q", tiff[:2])}
+	}
+
+	return readTIFF(&tiffSource{r: bytes.NewReader(tiff), size: int64(len(tiff)), bo: endian.ByteOrder()}, endian)
+}
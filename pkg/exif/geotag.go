@@ -0,0 +1,56 @@
+package exif
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/track"
+)
+
+// ErrNoTrackPoint is returned by Geotag when the track has no position
+// at the time the picture was taken.
+var ErrNoTrackPoint = errors.New("no track point at capture time")
+
+// GeotagOptions sets how pictures are matched to track points.
+type GeotagOptions struct {
+	// added to DateTimeOriginal to get the actual capture time: the error of
+	// the camera clock, positive when it's late
+	ClockOffset time.Duration
+
+	// time zone of the camera clock, used when DateTimeOriginal has no
+	// OffsetTimeOriginal (UTC when nil)
+	Location *time.Location
+
+	// track points farther apart aren't interpolated, the capture time is
+	// then matched to the closest one when within MaxGap, see track.At
+	MaxGap time.Duration
+}
+
+// Geotag sets the GPS IFD of m to the position of tr when the picture was
+// taken: GPSLatitude, GPSLongitude, GPSAltitude when the track has
+// elevations, and GPSTimeStamp and GPSDateStamp to the capture time.
+// It returns ErrNoDateTime when m has no DateTimeOriginal and
+// ErrNoTrackPoint when the capture time doesn't match the track.
+func (m *Metadata) Geotag(tr track.Track, opts GeotagOptions) (*GPS, error) {
+	t, err := m.DateTimeOriginal(opts.Location)
+	if err != nil {
+		return nil, err
+	}
+	t = t.Add(opts.ClockOffset)
+
+	p, ok := tr.At(t, opts.MaxGap)
+	if !ok {
+		return nil, errors.Wrapf(ErrNoTrackPoint, "%s", t.UTC().Format(time.RFC3339))
+	}
+	g := &GPS{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Altitude:  p.Elevation,
+		Time:      t.UTC(),
+	}
+	if err := m.SetGPS(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,281 @@
+package exif
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// ErrNoGPS is returned by GPSInfo when the GPS IFD has no position,
+// and by SetGPS for a nil GPS.
+var ErrNoGPS = errors.New("no GPS position")
+
+// ErrGPSRange is returned by SetGPS for a latitude or longitude out of
+// range, a negative DOP, or a value that isn't finite or doesn't fit
+// in a RATIONAL.
+var ErrGPSRange = errors.New("GPS value out of range")
+
+// gpsDateLayout is the layout of GPSDateStamp.
+const gpsDateLayout = "2006:01:02"
+
+// GPS is the content of the GPS IFD, with units applied.
+type GPS struct {
+	// signed decimal degrees, negative south of the equator
+	// and west of the prime meridian
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+
+	// meters, negative below sea level (nil when absent)
+	Altitude *float64 `json:"altitude,omitempty"`
+
+	// UTC time of GPSDateStamp and GPSTimeStamp (zero when absent)
+	Time time.Time `json:"time,omitzero"`
+
+	// speed of the receiver in SpeedRef units: "K" km/h, "M" mph, "N" knots
+	Speed    *float64 `json:"speed,omitempty"`
+	SpeedRef string   `json:"speedRef,omitempty"`
+
+	// directions in degrees, their ref is "T" for true north, "M" for magnetic north:
+	// Track is the direction of movement, ImgDirection the one the camera
+	// was pointing to and DestBearing the one of the destination point
+	Track           *float64 `json:"track,omitempty"`
+	TrackRef        string   `json:"trackRef,omitempty"`
+	ImgDirection    *float64 `json:"imgDirection,omitempty"`
+	ImgDirectionRef string   `json:"imgDirectionRef,omitempty"`
+	DestBearing     *float64 `json:"destBearing,omitempty"`
+	DestBearingRef  string   `json:"destBearingRef,omitempty"`
+
+	// dilution of precision (nil when absent)
+	DOP *float64 `json:"dop,omitempty"`
+}
+
+// GPSInfo returns the GPS IFD of m with units applied. It returns ErrNoGPS
+// when there is no GPS IFD or it has no GPSLatitude and GPSLongitude.
+func (m *Metadata) GPSInfo() (*GPS, error) {
+	if m == nil || m.GPS == nil {
+		return nil, ErrNoGPS
+	}
+	ifd := m.GPS
+
+	ascii := func(t tag.GPS) string {
+		e, _ := ifd.Get(uint16(t))
+		s, _ := e.Value.(string)
+		return strings.TrimSpace(s)
+	}
+	rationals := func(t tag.GPS) []float64 {
+		e, _ := ifd.Get(uint16(t))
+		rs, _ := e.Value.([]tag.Rational)
+		out := make([]float64, len(rs))
+		for i, r := range rs {
+			if !r.IsValid() {
+				return nil
+			}
+			out[i] = r.Float64()
+		}
+		return out
+	}
+	single := func(t tag.GPS) *float64 {
+		if v := rationals(t); len(v) == 1 {
+			return &v[0]
+		}
+		return nil
+	}
+
+	lat, lon := rationals(tag.GPSLatitude), rationals(tag.GPSLongitude)
+	if len(lat) != 3 || len(lon) != 3 {
+		return nil, ErrNoGPS
+	}
+
+	g := &GPS{
+		Latitude:        dmsToDecimal(lat),
+		Longitude:       dmsToDecimal(lon),
+		Speed:           single(tag.GPSSpeed),
+		SpeedRef:        ascii(tag.GPSSpeedRef),
+		Track:           single(tag.GPSTrack),
+		TrackRef:        ascii(tag.GPSTrackRef),
+		ImgDirection:    single(tag.GPSImgDirection),
+		ImgDirectionRef: ascii(tag.GPSImgDirectionRef),
+		DestBearing:     single(tag.GPSDestBearing),
+		DestBearingRef:  ascii(tag.GPSDestBearingRef),
+		DOP:             single(tag.GPSDOP),
+	}
+	if ascii(tag.GPSLatitudeRef) == "S" {
+		g.Latitude = -g.Latitude
+	}
+	if ascii(tag.GPSLongitudeRef) == "W" {
+		g.Longitude = -g.Longitude
+	}
+
+	if g.Altitude = single(tag.GPSAltitude); g.Altitude != nil {
+		// GPSAltitudeRef is 1 below sea level
+		if e, ok := ifd.Get(uint16(tag.GPSAltitudeRef)); ok {
+			if ref, _ := e.Value.([]byte); len(ref) > 0 && ref[0] == 1 {
+				*g.Altitude = -*g.Altitude
+			}
+		}
+	}
+
+	if date, err := time.Parse(gpsDateLayout, ascii(tag.GPSDateStamp)); err == nil {
+		if hms := rationals(tag.GPSTimeStamp); len(hms) == 3 {
+			g.Time = gpsDateTime(date, hms)
+		}
+	}
+	return g, nil
+}
+
+// SetGPS replaces the GPS IFD of m with the content of g: its position,
+// and its time, altitude, speed, directions and DOP when set. Speeds and
+// directions without ref are written in km/h and relative to true north.
+// m is left unchanged when g is nil or has invalid values.
+func (m *Metadata) SetGPS(g *GPS) error {
+	if m == nil {
+		return ErrNilMetadata
+	}
+	if g == nil {
+		return errors.Wrap(ErrNoGPS, "nil GPS")
+	}
+	if math.IsNaN(g.Latitude) || g.Latitude < -90 || g.Latitude > 90 {
+		return errors.Wrapf(ErrGPSRange, "latitude %v", g.Latitude)
+	}
+	if math.IsNaN(g.Longitude) || g.Longitude < -180 || g.Longitude > 180 {
+		return errors.Wrapf(ErrGPSRange, "longitude %v", g.Longitude)
+	}
+	for name, v := range map[string]*float64{
+		"altitude": g.Altitude, "speed": g.Speed, "track": g.Track,
+		"image direction": g.ImgDirection, "destination bearing": g.DestBearing, "DOP": g.DOP,
+	} {
+		if v != nil && (math.IsNaN(*v) || math.IsInf(*v, 0)) {
+			return errors.Wrapf(ErrGPSRange, "%s %v", name, *v)
+		}
+	}
+	if g.DOP != nil && *g.DOP < 0 {
+		return errors.Wrapf(ErrGPSRange, "DOP %v", *g.DOP)
+	}
+
+	latRef, lonRef := "N", "E"
+	if g.Latitude < 0 {
+		latRef = "S"
+	}
+	if g.Longitude < 0 {
+		lonRef = "W"
+	}
+
+	type field struct {
+		t tag.GPS
+		v any
+	}
+	fields := []field{
+		{tag.GPSVersionID, []byte{2, 3, 0, 0}},
+		{tag.GPSLatitudeRef, latRef},
+		{tag.GPSLatitude, decimalToDMS(g.Latitude)},
+		{tag.GPSLongitudeRef, lonRef},
+		{tag.GPSLongitude, decimalToDMS(g.Longitude)},
+	}
+
+	if g.Altitude != nil {
+		ref := byte(0)
+		if *g.Altitude < 0 {
+			ref = 1
+		}
+		alt, err := floatToRational(math.Abs(*g.Altitude), 1000)
+		if err != nil {
+			return errors.Wrap(err, "altitude")
+		}
+		fields = append(fields, field{tag.GPSAltitudeRef, []byte{ref}}, field{tag.GPSAltitude, alt})
+	}
+
+	if !g.Time.IsZero() {
+		stamp, date := gpsTimeStamp(g.Time)
+		fields = append(fields, field{tag.GPSTimeStamp, stamp}, field{tag.GPSDateStamp, date})
+	}
+
+	// optional value with its ref, defaulting to def
+	withRef := func(t tag.GPS, v *float64, refTag tag.GPS, ref, def string) error {
+		if v == nil {
+			return nil
+		}
+		if ref == "" {
+			ref = def
+		}
+		r, err := floatToRational(math.Abs(*v), 100)
+		if err != nil {
+			return errors.Wrapf(err, "%s", t)
+		}
+		fields = append(fields, field{refTag, ref}, field{t, r})
+		return nil
+	}
+	for _, err := range []error{
+		withRef(tag.GPSSpeed, g.Speed, tag.GPSSpeedRef, g.SpeedRef, "K"),
+		withRef(tag.GPSTrack, g.Track, tag.GPSTrackRef, g.TrackRef, "T"),
+		withRef(tag.GPSImgDirection, g.ImgDirection, tag.GPSImgDirectionRef, g.ImgDirectionRef, "T"),
+		withRef(tag.GPSDestBearing, g.DestBearing, tag.GPSDestBearingRef, g.DestBearingRef, "T"),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	if g.DOP != nil {
+		dop, err := floatToRational(*g.DOP, 100)
+		if err != nil {
+			return errors.Wrap(err, "DOP")
+		}
+		fields = append(fields, field{tag.GPSDOP, dop})
+	}
+
+	// the IFD is built apart, m keeping its GPS IFD on error
+	gps := &Metadata{}
+	for _, f := range fields {
+		if err := gps.Set(f.t, f.v); err != nil {
+			return err
+		}
+	}
+	m.GPS = gps.GPS
+	return nil
+}
+
+// gpsDateTime returns the time of GPSDateStamp date and GPSTimeStamp hms,
+// hours, minutes and seconds, rounded to the millisecond.
+func gpsDateTime(date time.Time, hms []float64) time.Time {
+	sec := time.Duration(hms[0]*float64(time.Hour) + hms[1]*float64(time.Minute) + hms[2]*float64(time.Second))
+	return date.Add(sec.Round(time.Millisecond))
+}
+
+// gpsTimeStamp returns the GPSTimeStamp and GPSDateStamp values of t in UTC,
+// the seconds with a precision of a millisecond.
+func gpsTimeStamp(t time.Time) ([]tag.Rational, string) {
+	t = t.UTC()
+	ms := t.Second()*1000 + t.Nanosecond()/int(time.Millisecond)
+	return []tag.Rational{{Num: uint32(t.Hour()), Den: 1}, {Num: uint32(t.Minute()), Den: 1}, {Num: uint32(ms), Den: 1000}},
+		t.Format(gpsDateLayout)
+}
+
+// dmsToDecimal returns degrees, minutes and seconds as decimal degrees.
+func dmsToDecimal(dms []float64) float64 {
+	return dms[0] + dms[1]/60 + dms[2]/3600
+}
+
+// decimalToDMS returns the absolute value of deg as degrees, minutes and
+// seconds rationals, the seconds with a precision of 1/10000.
+func decimalToDMS(deg float64) []tag.Rational {
+	const secDen = 10000
+	total := int64(math.Round(math.Abs(deg) * 3600 * secDen))
+	return []tag.Rational{
+		{Num: uint32(total / (3600 * secDen)), Den: 1},
+		{Num: uint32(total / (60 * secDen) % 60), Den: 1},
+		{Num: uint32(total % (60 * secDen)), Den: secDen},
+	}
+}
+
+// floatToRational returns f as a rational of denominator den. It returns
+// ErrGPSRange when f is negative or too large for a RATIONAL.
+func floatToRational(f float64, den uint32) (tag.Rational, error) {
+	num := math.Round(f * float64(den))
+	if !(num >= 0 && num <= math.MaxUint32) {
+		return tag.Rational{}, errors.Wrapf(ErrGPSRange, "%v doesn't fit in a RATIONAL of denominator %d", f, den)
+	}
+	return tag.Rational{Num: uint32(num), Den: den}, nil
+}
@@ -0,0 +1,155 @@
+package exif
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"exif/pkg/tag"
+)
+
+func TestSetGPSInvalid(t *testing.T) {
+	m := &Metadata{}
+	if err := m.SetGPS(nil); !errors.Is(err, ErrNoGPS) {
+		t.Errorf("SetGPS(nil): got %v, want ErrNoGPS", err)
+	}
+
+	f := func(v float64) *float64 { return &v }
+	for _, g := range []GPS{
+		{Latitude: 90.5},
+		{Latitude: math.NaN()},
+		{Longitude: -180.5},
+		{Altitude: f(math.Inf(1))},
+		{Speed: f(math.NaN())},
+		{DOP: f(-1)},
+	} {
+		if err := m.SetGPS(&g); !errors.Is(err, ErrGPSRange) {
+			t.Errorf("SetGPS(%+v): got %v, want ErrGPSRange", g, err)
+		}
+	}
+	if m.GPS != nil {
+		t.Error("GPS IFD created")
+	}
+}
+
+func TestFloatToRational(t *testing.T) {
+	tests := []struct {
+		f    float64
+		den  uint32
+		want tag.Rational
+		ok   bool
+	}{
+		{0, 100, tag.Rational{Num: 0, Den: 100}, true},
+		{12.345, 100, tag.Rational{Num: 1235, Den: 100}, true},
+		{8848.86, 1000, tag.Rational{Num: 8848860, Den: 1000}, true},
+		{math.MaxUint32 / 1000, 1000, tag.Rational{Num: math.MaxUint32 / 1000 * 1000, Den: 1000}, true},
+		{-0.5, 100, tag.Rational{}, false},
+		{5e6, 1000, tag.Rational{}, false},
+		{math.Inf(1), 100, tag.Rational{}, false},
+		{math.NaN(), 100, tag.Rational{}, false},
+	}
+	for _, tt := range tests {
+		got, err := floatToRational(tt.f, tt.den)
+		if tt.ok != (err == nil) || got != tt.want {
+			t.Errorf("floatToRational(%v, %d) = %v, %v, want %v", tt.f, tt.den, got, err, tt.want)
+		}
+		if !tt.ok && !errors.Is(err, ErrGPSRange) {
+			t.Errorf("floatToRational(%v, %d): got %v, want ErrGPSRange", tt.f, tt.den, err)
+		}
+	}
+
+	m := &Metadata{}
+	alt := 5e6
+	if err := m.SetGPS(&GPS{Altitude: &alt}); !errors.Is(err, ErrGPSRange) {
+		t.Errorf("SetGPS with an altitude of %v m: got %v, want ErrGPSRange", alt, err)
+	}
+}
+
+func TestDecimalToDMS(t *testing.T) {
+	tests := []struct {
+		deg  float64
+		want []tag.Rational
+	}{
+		{0, []tag.Rational{{Num: 0, Den: 1}, {Num: 0, Den: 1}, {Num: 0, Den: 10000}}},
+		{43.4675, []tag.Rational{{Num: 43, Den: 1}, {Num: 28, Den: 1}, {Num: 30000, Den: 10000}}},
+		{-122.4194, []tag.Rational{{Num: 122, Den: 1}, {Num: 25, Den: 1}, {Num: 98400, Den: 10000}}},
+		// rounding the seconds carries to the minutes and degrees
+		{89.99999999, []tag.Rational{{Num: 90, Den: 1}, {Num: 0, Den: 1}, {Num: 0, Den: 10000}}},
+		{180, []tag.Rational{{Num: 180, Den: 1}, {Num: 0, Den: 1}, {Num: 0, Den: 10000}}},
+	}
+	for _, tt := range tests {
+		got := decimalToDMS(tt.deg)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("decimalToDMS(%v) = %v, want %v", tt.deg, got, tt.want)
+		}
+		dms := make([]float64, len(got))
+		for i, r := range got {
+			dms[i] = r.Float64()
+		}
+		if d := dmsToDecimal(dms); math.Abs(d-math.Abs(tt.deg)) > 1e-6 {
+			t.Errorf("dmsToDecimal(decimalToDMS(%v)) = %v", tt.deg, d)
+		}
+	}
+}
+
+func TestSetGPSRoundTrip(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	tests := []struct {
+		g              GPS
+		latRef, lonRef string
+		altRef         byte
+	}{
+		{GPS{Latitude: 45.725, Longitude: 5.249, Altitude: f(210.5)}, "N", "E", 0},
+		{GPS{Latitude: -33.85, Longitude: 151.2, Altitude: f(-12.25)}, "S", "E", 1},
+		{GPS{Latitude: 40.7128, Longitude: -74.006, Altitude: f(0)}, "N", "W", 0},
+		{GPS{Latitude: -0.0001, Longitude: -179.9999}, "S", "W", 0},
+		{GPS{
+			Latitude: 64.1466, Longitude: -21.9426,
+			Time:  time.Date(2021, 12, 31, 23, 59, 59, 500e6, time.UTC),
+			Speed: f(12.5), SpeedRef: "N", Track: f(270.25), ImgDirection: f(90), ImgDirectionRef: "M",
+			DestBearing: f(1.5), DOP: f(0.9),
+		}, "N", "W", 0},
+	}
+	for _, tt := range tests {
+		m := &Metadata{}
+		if err := m.SetGPS(&tt.g); err != nil {
+			t.Fatal(err)
+		}
+		if e, _ := m.Get(tag.GPSLatitudeRef); e.Value != tt.latRef {
+			t.Errorf("%v: GPSLatitudeRef %#v, want %q", tt.g.Latitude, e.Value, tt.latRef)
+		}
+		if e, _ := m.Get(tag.GPSLongitudeRef); e.Value != tt.lonRef {
+			t.Errorf("%v: GPSLongitudeRef %#v, want %q", tt.g.Longitude, e.Value, tt.lonRef)
+		}
+		if e, ok := m.Get(tag.GPSAltitudeRef); ok != (tt.g.Altitude != nil) || (ok && !reflect.DeepEqual(e.Value, []byte{tt.altRef})) {
+			t.Errorf("%v: GPSAltitudeRef %#v, want %d", tt.g.Altitude, e.Value, tt.altRef)
+		}
+
+		got, err := m.GPSInfo()
+		if err != nil {
+			t.Fatal(err)
+		}
+		same := func(a, b *float64) bool {
+			return (a == nil) == (b == nil) && (a == nil || math.Abs(*a-*b) < 1e-3)
+		}
+		// refs default to km/h and true north
+		ref := func(v *float64, r, def string) string {
+			if v != nil && r == "" {
+				return def
+			}
+			return r
+		}
+		want := tt.g
+		if math.Abs(got.Latitude-want.Latitude) > 1e-7 || math.Abs(got.Longitude-want.Longitude) > 1e-7 ||
+			!same(got.Altitude, want.Altitude) || !got.Time.Equal(want.Time) ||
+			!same(got.Speed, want.Speed) || got.SpeedRef != ref(want.Speed, want.SpeedRef, "K") ||
+			!same(got.Track, want.Track) || got.TrackRef != ref(want.Track, want.TrackRef, "T") ||
+			!same(got.ImgDirection, want.ImgDirection) || got.ImgDirectionRef != ref(want.ImgDirection, want.ImgDirectionRef, "T") ||
+			!same(got.DestBearing, want.DestBearing) || got.DestBearingRef != ref(want.DestBearing, want.DestBearingRef, "T") ||
+			!same(got.DOP, want.DOP) {
+			t.Errorf("GPSInfo() = %+v, want %+v", *got, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package exif
+
+import (
+	tagpkg "exif/pkg/tag"
+)
+
+// IfdEntry represents a parsed EXIF IFD entry.
+type IfdEntry struct {
+	TagID  uint16 `json:"tagId"`
+	TypeID uint16 `json:"typeId"`
+	Count  uint64 `json:"count"`
+	Value  any    `json:"value"`
+
+	// registry name of the tag, "UnknownTag(0xXXXX)" when it's not registered
+	Name string `json:"name"`
+
+	// position of the entry in its IFD
+	Index int `json:"index"`
+
+	// offset of the 12 bytes entry (20 bytes in BigTIFF), relative to the TIFF header
+	Offset uint64 `json:"offset"`
+}
+
+// IFD is an Image File Directory. Entries are kept in on-disk order,
+// duplicated tags included.
+type IFD struct {
+	// tag namespace of the entries
+	Namespace tagpkg.IFD `json:"namespace"`
+
+	// offset of the IFD, relative to the TIFF header
+	Offset uint64 `json:"offset"`
+
+	Entries []IfdEntry `json:"entries"`
+
+	// offset of the next IFD of the chain, 0 for the last one
+	Next uint64 `json:"next"`
+}
+
+// Get returns the first entry of tag id.
+func (d *IFD) Get(id uint16) (IfdEntry, bool) {
+	if d == nil {
+		return IfdEntry{}, false
+	}
+	for _, e := range d.Entries {
+		if e.TagID == id {
+			return e, true
+		}
+	}
+	return IfdEntry{}, false
+}
+
+// GetAll returns every entry of tag id, more than one means the tag is duplicated.
+func (d *IFD) GetAll(id uint16) []IfdEntry {
+	if d == nil {
+		return nil
+	}
+	var entries []IfdEntry
+	for _, e := range d.Entries {
+		if e.TagID == id {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Find returns the first entry named name.
+func (d *IFD) Find(name string) (IfdEntry, bool) {
+	if d == nil {
+		return IfdEntry{}, false
+	}
+	for _, e := range d.Entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return IfdEntry{}, false
+}
+
+// Duplicates returns the IDs of the tags found more than once, in on-disk order.
+func (d *IFD) Duplicates() []uint16 {
+	if d == nil {
+		return nil
+	}
+	var (
+		dups []uint16
+		seen = make(map[uint16]int, len(d.Entries))
+	)
+	for _, e := range d.Entries {
+		if seen[e.TagID]++; seen[e.TagID] == 2 {
+			dups = append(dups, e.TagID)
+		}
+	}
+	return dups
+}
+
+// parseIFD parses the IFD of t at the given offset, the returned IFD holds
+// the next IFD offset. Offsets are relative to the TIFF header.
+// ns is the tag namespace of the IFD, used to name its entries, and path names
+// the IFD being walked (e.g. "IFD0/GPS") and is reported in errors.
+func parseIFD(t *tiffSource, offset uint64, ns tagpkg.IFD, path string) (*IFD, error) {
+	bo := t.bo
+
+	// classic TIFF: 2 bytes count, 12 bytes entries, 4 bytes offsets
+	// BigTIFF: 8 bytes count, 20 bytes entries, 8 bytes offsets
+	countSize, entrySize, offSize := uint64(2), uint64(12), uint64(4)
+	if t.big {
+		countSize, entrySize, offSize = 8, 20, 8
+	}
+
+	if !t.fits(offset, countSize) {
+		return nil, formatErr(ErrTruncated, path, offset, "data too short for IFD count")
+	}
+	raw, err := t.bytes(offset, countSize)
+	if err != nil {
+		return nil, err
+	}
+	num := uint64(bo.Uint16(raw))
+	if t.big {
+		num = bo.Uint64(raw)
+	}
+
+	// entries and next pointer are read at once
+	start := offset + countSize
+	if avail := uint64(t.size) - start; num > avail/entrySize {
+		return nil, formatErr(ErrTruncated, path, start+avail/entrySize*entrySize, "data too short for IFD entry %d", avail/entrySize)
+	}
+	if !t.fits(start, num*entrySize+offSize) {
+		return nil, formatErr(ErrTruncated, path, start+num*entrySize, "data too short for next IFD pointer")
+	}
+	block, err := t.bytes(start, num*entrySize+offSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ifd := &IFD{Namespace: ns, Offset: offset, Entries: make([]IfdEntry, 0, num)}
+	for i := range int(num) {
+		e := block[uint64(i)*entrySize:]
+		pos := start + uint64(i)*entrySize
+
+		tag := bo.Uint16(e[0:2])
+		tp := bo.Uint16(e[2:4])
+		var (
+			cnt  uint64
+			valp []byte
+		)
+		if t.big {
+			cnt, valp = bo.Uint64(e[4:12]), e[12:20]
+		} else {
+			cnt, valp = uint64(bo.Uint32(e[4:8])), e[8:12]
+		}
+
+		size, ok := tagpkg.TypeSizes[tagpkg.Type(tp)]
+		if !ok {
+			// the size of the value is unknown, the value field is kept
+			// as is and the entry isn't re-encoded
+			ifd.Entries = append(ifd.Entries, IfdEntry{
+				TagID:  tag,
+				TypeID: tp,
+				Count:  cnt,
+				Value:  append([]byte(nil), valp...),
+				Name:   tagpkg.Name(ns, tag),
+				Index:  i,
+				Offset: pos,
+			})
+			continue
+		}
+
+		// values that don't fit in the value field live at an offset
+		if cnt > uint64(t.size)/uint64(size)+1 {
+			return nil, formatErr(ErrOffsetOutOfRange, path, pos, "tag 0x%04X count %d overruns data (%d)", tag, cnt, t.size)
+		}
+		n := cnt * uint64(size)
+		raw := valp[:min(n, offSize)]
+		if n > offSize {
+			off := uint64(bo.Uint32(valp))
+			if t.big {
+				off = bo.Uint64(valp)
+			}
+			if !t.fits(off, n) {
+				return nil, formatErr(ErrOffsetOutOfRange, path, off, "tag 0x%04X value of %d bytes overruns data (%d)", tag, n, t.size)
+			}
+			if raw, err = t.bytes(off, n); err != nil {
+				return nil, err
+			}
+		}
+
+		ifd.Entries = append(ifd.Entries, IfdEntry{
+			TagID:  tag,
+			TypeID: tp,
+			Count:  cnt,
+			Value:  decodeValue(bo, tagpkg.Type(tp), cnt, raw),
+			Name:   tagpkg.Name(ns, tag),
+			Index:  i,
+			Offset: pos,
+		})
+	}
+
+	next := block[num*entrySize:]
+	ifd.Next = uint64(bo.Uint32(next))
+	if t.big {
+		ifd.Next = bo.Uint64(next)
+	}
+	return ifd, nil
+}
+
+// Format returns the value of e, an entry of d, with the print conversion
+// of its tag (see tag.Format), e.g. "Horizontal (normal)" for an Orientation
+// of 1. The raw value stays in e.Value.
+func (d *IFD) Format(e IfdEntry) string {
+	if d == nil {
+		return tagpkg.FormatValue(e.Value)
+	}
+	return tagpkg.Format(tagpkg.Key{IFD: d.Namespace, ID: e.TagID}, e.Value)
+}
@@ -0,0 +1,240 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	"github.com/pkg/errors"
+)
+
+type UnitType byte
+
+const (
+	Unit_UNKNOWN UnitType = iota // 0
+	Unit_INCH                    // 1
+	Unit_CM                      // 2
+)
+
+type ExtensionCode byte
+
+const (
+	// 0x10 Thumbnail coded using JPEG
+	ExtensionCode_JPEG ExtensionCode = 0x10
+
+	// 0x11 Thumbnail using 1 byte/pixel
+	ExtensionCode_BYTE_PER_PIXEL ExtensionCode = 0x11
+
+	// 0x13 Thumbnail using 3 bytes/pixel
+	ExtensionCode_3_BYTES_PER_PIXEL ExtensionCode = 0x13
+)
+
+// JFIF APP0 Segments are used in the old JFIF standard
+// to store information about the picture dimensions and
+// an optional thumbnail.
+// The format of a JFIF APP0 segment is as follows
+// (not that size of thumbnail data is 3n, where n = Xthumbnal * Ythumbnail, it's present if n is not zero; only the first 8 records are mandatory):
+// There is also an extended JFIF
+// (only possible for JFIF versions 1.02 and above).
+// In this case the identifier is not JFIF but JFXX.
+// This extension allows for the inclusion of differently
+// encoded thumbnails. The syntax in this case is modified as follows:
+type APP0 struct {
+	// (JFIF\000 = 0x4a46494600) or (JFXX\000 = 0x4a46585800; on JFIF v1.02+)
+	Identifier string `json:"identifier"`
+
+	// thumbnail image data
+	ThumbnailData []byte `json:"thumbnailData"`
+
+	// horizontal pixel density
+	XDensity int `json:"xDensity"`
+
+	// vertical pixel density
+	YDensity int `json:"yDensity"`
+
+	MajorVersion byte `json:"majorVersion"`
+
+	MinorVersion byte `json:"minorVersion"`
+
+	Units UnitType `json:"units"`
+
+	// thumbnail horizontal pixel count
+	XThumbnail byte `json:"xThumbnail"`
+
+	// thumbnail vertical pixel count
+	YThumbnail byte `json:"yThumbnail"`
+
+	// (0x10 Thumbnail coded using JPEG
+	ExtensionCode ExtensionCode `json:"extensionCode"`
+
+	// If the thumbnail is coded using a JPEG stream,
+	// a binary JPEG stream immediately follows the
+	// extension code (the byte count of this file is
+	// included in the byte count of the APP0 Segment).
+	// This stream conforms to the syntax for a JPEG file
+	// (SOI .... SOF ... EOI);
+	// however, no 'JFIF' or 'JFXX' marker Segments should be present:
+	// a variable length JPEG picture of thumbnail
+	JPEGThumbnail []byte `json:"jpegThumbnail"`
+
+	// If the thumbnail is stored using one byte per pixel,
+	// after the extension code one should find a palette
+	// and an indexed RGB. The records are as follows
+	// (remember that n = Xthumbnail * Ythumbnail):
+
+	// 24-bit RGB values for the colour palette
+	// (defining the colours represented by each
+	// value of an 8-bit binary enconding)
+	ColorPalette []byte `json:"collorPalette"`
+
+	// 8-bit indexed values for the thumbnail
+	OneByteThumbnail []byte `json:"oneByteThumbnail"`
+
+	// If the thumbnail is stored using three bytes per pixel,
+	// there is no colour palette, so the previous fields simplify into:
+	// 24-bit RGB values for the thumbnail
+	ThreeBytesThumbnail []byte `json:"threeBytesThumbnail"`
+}
+
+// 256 colors of 24-bit RGB
+const paletteSize = 768
+
+// ParseAPP0 parses a JFIF or JFXX APP0 segment (starting with its 0xFFE0 marker).
+// Offsets reported in errors are relative to the start of the segment.
+func ParseAPP0(v []byte) (*APP0, error) {
+	// marker, size and identifier
+	if len(v) < segmentHeaderLen+5 {
+		return nil, &FormatError{Err: ErrTruncated, Msg: fmt.Sprintf("APP0 segment of %d bytes is too short for an identifier", len(v))}
+	}
+
+	if !bytes.Equal(v[:2], []byte{markerPrefix, byte(MarkerAPP0)}) {
+		return nil, &FormatError{Err: ErrSignature, Msg: fmt.Sprintf("marker 0x%02X%02X, want 0xFFE0", v[0], v[1])}
+	}
+
+	// the size includes itself but not the marker
+	if size := int(binary.BigEndian.Uint16(v[2:4])); size+2 < len(v) {
+		v = v[:size+2]
+	}
+
+	app0 := &APP0{Identifier: string(v[4:9])}
+	data := v[9:]
+	pos := 9 // offset of data in the segment
+
+	switch app0.Identifier {
+	case SignatureJFIF:
+		// version (2), units (1), densities (2 x 2), thumbnail dimensions (2)
+		if len(data) < 9 {
+			return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: "JFIF header is too short"}
+		}
+		app0.MajorVersion = data[0]
+		app0.MinorVersion = data[1]
+		app0.Units = UnitType(data[2])
+		app0.XDensity = int(binary.BigEndian.Uint16(data[3:5]))
+		app0.YDensity = int(binary.BigEndian.Uint16(data[5:7]))
+		app0.XThumbnail = data[7]
+		app0.YThumbnail = data[8]
+
+		n := 3 * int(app0.XThumbnail) * int(app0.YThumbnail)
+		if len(data)-9 < n {
+			return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos + 9), Msg: fmt.Sprintf("want %d bytes of RGB thumbnail, have %d", n, len(data)-9)}
+		}
+		if n > 0 {
+			app0.ThumbnailData = data[9 : 9+n]
+		}
+
+	case SignatureJFXX:
+		if len(data) < 1 {
+			return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: "JFXX extension code is missing"}
+		}
+		app0.ExtensionCode = ExtensionCode(data[0])
+		data, pos = data[1:], pos+1
+
+		switch app0.ExtensionCode {
+		case ExtensionCode_JPEG:
+			app0.JPEGThumbnail = data
+			app0.ThumbnailData = data
+
+		case ExtensionCode_BYTE_PER_PIXEL:
+			// dimensions (2), palette (768) and n indexes
+			if len(data) < 2+paletteSize {
+				return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: "JFXX palette thumbnail header is too short"}
+			}
+			app0.XThumbnail = data[0]
+			app0.YThumbnail = data[1]
+			app0.ColorPalette = data[2 : 2+paletteSize]
+			data, pos = data[2+paletteSize:], pos+2+paletteSize
+
+			n := int(app0.XThumbnail) * int(app0.YThumbnail)
+			if len(data) < n {
+				return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: fmt.Sprintf("want %d bytes of indexed thumbnail, have %d", n, len(data))}
+			}
+			app0.OneByteThumbnail = data[:n]
+			app0.ThumbnailData = data[:n]
+
+		case ExtensionCode_3_BYTES_PER_PIXEL:
+			if len(data) < 2 {
+				return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: "JFXX RGB thumbnail header is too short"}
+			}
+			app0.XThumbnail = data[0]
+			app0.YThumbnail = data[1]
+			data, pos = data[2:], pos+2
+
+			n := 3 * int(app0.XThumbnail) * int(app0.YThumbnail)
+			if len(data) < n {
+				return nil, &FormatError{Err: ErrTruncated, Offset: int64(pos), Msg: fmt.Sprintf("want %d bytes of RGB thumbnail, have %d", n, len(data))}
+			}
+			app0.ThreeBytesThumbnail = data[:n]
+			app0.ThumbnailData = data[:n]
+
+		default:
+			return nil, &FormatError{Err: ErrExtensionCode, Offset: int64(pos - 1), Msg: fmt.Sprintf("got 0x%02X", byte(app0.ExtensionCode))}
+		}
+
+	default:
+		return nil, &FormatError{Err: ErrSignature, Offset: 4, Msg: fmt.Sprintf("got %q", app0.Identifier)}
+	}
+
+	return app0, nil
+}
+
+// Thumbnail decodes the thumbnail carried by the segment, whatever its encoding.
+// It returns ErrNoThumbnail when the segment has none.
+func (a *APP0) Thumbnail() (image.Image, error) {
+	if a == nil || len(a.ThumbnailData) == 0 {
+		return nil, ErrNoThumbnail
+	}
+
+	w, h := int(a.XThumbnail), int(a.YThumbnail)
+	rect := image.Rect(0, 0, w, h)
+
+	switch {
+	case a.Identifier == SignatureJFXX && a.ExtensionCode == ExtensionCode_JPEG:
+		img, err := jpeg.Decode(bytes.NewReader(a.JPEGThumbnail))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode JPEG thumbnail")
+		}
+		return img, nil
+
+	case a.Identifier == SignatureJFXX && a.ExtensionCode == ExtensionCode_BYTE_PER_PIXEL:
+		palette := make(color.Palette, 256)
+		for i := range palette {
+			p := a.ColorPalette[i*3 : i*3+3]
+			palette[i] = color.RGBA{R: p[0], G: p[1], B: p[2], A: 0xFF}
+		}
+		img := image.NewPaletted(rect, palette)
+		copy(img.Pix, a.OneByteThumbnail)
+		return img, nil
+
+	default:
+		// JFIF and JFXX 3 bytes/pixel thumbnails are both packed RGB
+		img := image.NewRGBA(rect)
+		for i := range w * h {
+			p := a.ThumbnailData[i*3 : i*3+3]
+			img.Pix[i*4], img.Pix[i*4+1], img.Pix[i*4+2], img.Pix[i*4+3] = p[0], p[1], p[2], 0xFF
+		}
+		return img, nil
+	}
+}
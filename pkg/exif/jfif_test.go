@@ -0,0 +1,118 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// app0 returns an APP0 segment with the identifier and data.
+func app0(id string, data []byte) []byte {
+	seg := []byte{markerPrefix, byte(MarkerAPP0), 0, 0}
+	seg = append(seg, id...)
+	seg = append(seg, data...)
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(seg)-2))
+	return seg
+}
+
+func TestParseAPP0JFIF(t *testing.T) {
+	// version 1.02, dots per inch, 72x72, 1x1 thumbnail
+	a, err := ParseAPP0(app0(SignatureJFIF, []byte{1, 2, 1, 0, 72, 0, 72, 1, 1, 0xFF, 0x80, 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.MajorVersion != 1 || a.MinorVersion != 2 || a.Units != Unit_INCH || a.XDensity != 72 || a.YDensity != 72 {
+		t.Errorf("got %+v", a)
+	}
+	img, err := a.Thumbnail()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := color.RGBAModel.Convert(img.At(0, 0)); got != (color.RGBA{0xFF, 0x80, 0, 0xFF}) {
+		t.Errorf("thumbnail pixel %v", got)
+	}
+}
+
+func TestParseAPP0JFXX(t *testing.T) {
+	palette := make([]byte, paletteSize)
+	copy(palette[3*7:], []byte{10, 20, 30})
+	copy(palette[3*9:], []byte{40, 50, 60})
+
+	tests := []struct {
+		name   string
+		data   []byte
+		code   ExtensionCode
+		pixels []color.RGBA // 2x1
+	}{
+		{
+			name:   "palette",
+			data:   append(append([]byte{byte(ExtensionCode_BYTE_PER_PIXEL), 2, 1}, palette...), 7, 9),
+			code:   ExtensionCode_BYTE_PER_PIXEL,
+			pixels: []color.RGBA{{10, 20, 30, 0xFF}, {40, 50, 60, 0xFF}},
+		},
+		{
+			name:   "RGB",
+			data:   []byte{byte(ExtensionCode_3_BYTES_PER_PIXEL), 2, 1, 1, 2, 3, 4, 5, 6},
+			code:   ExtensionCode_3_BYTES_PER_PIXEL,
+			pixels: []color.RGBA{{1, 2, 3, 0xFF}, {4, 5, 6, 0xFF}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseAPP0(app0(SignatureJFXX, tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.ExtensionCode != tt.code || a.XThumbnail != 2 || a.YThumbnail != 1 {
+				t.Errorf("extension 0x%02X, thumbnail %dx%d", byte(a.ExtensionCode), a.XThumbnail, a.YThumbnail)
+			}
+			img, err := a.Thumbnail()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if img.Bounds() != image.Rect(0, 0, 2, 1) {
+				t.Fatalf("bounds %v", img.Bounds())
+			}
+			for x, want := range tt.pixels {
+				if got := color.RGBAModel.Convert(img.At(x, 0)); got != want {
+					t.Errorf("pixel %d = %v, want %v", x, got, want)
+				}
+			}
+
+			// every byte is needed
+			for n := len(tt.data) - 1; n > 0; n-- {
+				if _, err := ParseAPP0(app0(SignatureJFXX, tt.data[:n])); !errors.Is(err, ErrTruncated) {
+					t.Fatalf("%d bytes: got %v, want ErrTruncated", n, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAPP0Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		seg  []byte
+		want error
+	}{
+		{"APP1 marker", append([]byte{markerPrefix, byte(MarkerAPP1)}, app0(SignatureJFIF, make([]byte, 9))[2:]...), ErrSignature},
+		{"identifier", app0("JFIX\x00", make([]byte, 9)), ErrSignature},
+		{"extension code", app0(SignatureJFXX, []byte{0x12}), ErrExtensionCode},
+		{"short", app0("JF", nil), ErrTruncated},
+		{"JFIF header", app0(SignatureJFIF, make([]byte, 8)), ErrTruncated},
+		{"JFIF thumbnail", app0(SignatureJFIF, []byte{1, 2, 1, 0, 72, 0, 72, 2, 2, 0}), ErrTruncated},
+	}
+	for _, tt := range tests {
+		_, err := ParseAPP0(tt.seg)
+		var fe *FormatError
+		if !errors.Is(err, tt.want) || !errors.As(err, &fe) {
+			t.Errorf("%s: got %v, want a FormatError of %v", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := (&APP0{}).Thumbnail(); !errors.Is(err, ErrNoThumbnail) {
+		t.Errorf("no thumbnail: got %v, want ErrNoThumbnail", err)
+	}
+}
@@ -0,0 +1,160 @@
+package exif
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// JPEG files start with a Start of Image (SOI) marker (0xFFD8)
+// and contain various segments, each beginning with a marker (0xFF followed by a segment code).
+// Metadata is typically stored in segments like APP0, APP1, APP2,
+// APP13, and COM before the Start of Scan (SOS, 0xFFDA) marker.
+
+// The Identify Segments can look by `0xFF` followed by a segment code
+
+// Its length is unknown in advance, nor defined in the file.
+// The only way to get its length is to either decode it or to
+// fast-forward over it: just scan forward for a FF byte.
+// If it's a restart marker (followed by D0 - D7)
+// or a data FF (followed by 00), continue.
+
+// SegmentReader reads the segments of a JPEG stream one at a time,
+// without buffering more than the segment being read.
+type SegmentReader struct {
+	// ReadScan makes Next go on past SOS: the entropy-coded data is
+	// skipped up to the next marker instead of ending the iteration.
+	ReadScan bool
+
+	r       *bufio.Reader
+	pos     int64 // bytes consumed from r
+	started bool
+	inScan  bool
+	done    bool
+}
+
+// NewSegmentReader returns a SegmentReader reading from r.
+func NewSegmentReader(r io.Reader) *SegmentReader {
+	return &SegmentReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the marker of the next segment (e.g. MarkerAPP1) and its
+// payload, without the marker and the length field; standalone markers (SOI, EOI,
+// RSTn) have a nil payload. It returns io.EOF after EOI, and after SOS unless
+// ReadScan is set, in which case Rest returns the scan data that follows.
+func (s *SegmentReader) Next() (marker Marker, payload []byte, err error) {
+	if s.done {
+		return 0, nil, io.EOF
+	}
+
+	if !s.started {
+		s.started = true
+		var soi [2]byte
+		if _, err = io.ReadFull(s.r, soi[:]); err != nil || soi != [2]byte{markerPrefix, byte(MarkerSOI)} {
+			s.done = true
+			return 0, nil, &FormatError{Err: ErrNotJPEG, Msg: fmt.Sprintf("starts with %X", soi)}
+		}
+		s.pos += 2
+		return MarkerSOI, nil, nil
+	}
+
+	if marker, err = s.nextMarker(); err != nil {
+		s.done = true
+		return 0, nil, err
+	}
+
+	if marker.IsStandalone() {
+		s.done = marker == MarkerEOI
+		return marker, nil, nil
+	}
+
+	// otherwise the two bytes after the marker are a big-endian length,
+	// which counts itself
+	var size [2]byte
+	if _, err = io.ReadFull(s.r, size[:]); err != nil {
+		s.done = true
+		return 0, nil, &FormatError{Err: ErrTruncated, Offset: s.pos, Msg: fmt.Sprintf("truncated length of segment %s", marker)}
+	}
+	length := int(binary.BigEndian.Uint16(size[:]))
+	if length < 2 {
+		s.done = true
+		return 0, nil, &FormatError{Err: ErrTruncated, Offset: s.pos, Msg: fmt.Sprintf("segment %s has an invalid length %d", marker, length)}
+	}
+	s.pos += 2
+
+	payload = make([]byte, length-2)
+	if _, err = io.ReadFull(s.r, payload); err != nil {
+		s.done = true
+		return 0, nil, &FormatError{Err: ErrTruncated, Offset: s.pos, Msg: fmt.Sprintf("segment %s overruns stream: want %d bytes", marker, len(payload))}
+	}
+	s.pos += int64(len(payload))
+
+	if marker == MarkerSOS {
+		s.inScan = s.ReadScan
+		s.done = !s.ReadScan
+	}
+	return marker, payload, nil
+}
+
+// nextMarker reads up to the next marker code, skipping fill bytes and,
+// within a scan, the entropy-coded data.
+func (s *SegmentReader) nextMarker() (Marker, error) {
+	sawFF := false
+	for {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				// a stream ending without EOI is tolerated
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+		s.pos++
+
+		switch {
+		case !sawFF:
+			// bytes outside of a marker are entropy-coded data,
+			// or garbage that's skipped like it
+			sawFF = b == markerPrefix
+		case b == markerPrefix:
+			// skip any padding FF's (0xFF 0xFF ..)
+		case b == 0x00 || (s.inScan && Marker(b) >= MarkerRST0 && Marker(b) <= MarkerRST7):
+			// 0x00 is a "stuffed" zero byte and RSTn are restart
+			// markers of the scan, both belong to the entropy-coded data
+			sawFF = false
+		default:
+			s.inScan = false
+			return Marker(b), nil
+		}
+	}
+}
+
+// Rest returns the data following the last segment returned by Next,
+// i.e. the entropy-coded data once Next returned SOS.
+func (s *SegmentReader) Rest() io.Reader {
+	return s.r
+}
+
+// Offset returns the number of bytes consumed from the stream.
+func (s *SegmentReader) Offset() int64 {
+	return s.pos
+}
+
+// NextSegment is Next returning a typed segment.
+func (s *SegmentReader) NextSegment() (Segment, error) {
+	marker, payload, err := s.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	seg, err := NewSegment(marker, payload)
+	if err != nil {
+		if fe, ok := err.(*FormatError); ok {
+			// make the offset relative to the stream
+			fe.Offset += s.pos - int64(len(payload))
+		}
+		return nil, err
+	}
+	return seg, nil
+}
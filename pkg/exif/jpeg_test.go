@@ -0,0 +1,160 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// sosEnd returns the offset following the SOS segment of data, walking
+// the length fields of the segments before it.
+func sosEnd(t *testing.T, data []byte) int64 {
+	t.Helper()
+	for i := 2; i+4 <= len(data); {
+		if data[i] != markerPrefix {
+			t.Fatalf("no marker at %d", i)
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if Marker(data[i+1]) == MarkerSOS {
+			return int64(end)
+		}
+		i = end
+	}
+	t.Fatal("no SOS segment")
+	return 0
+}
+
+func TestSegmentReaderStopsAtSOS(t *testing.T) {
+	for _, path := range []string{"../../DSCN0012.jpg", "../../fujifilm-dx10.jpg", "../../image.jpeg"} {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := sosEnd(t, data)
+
+			cr := &countingReader{r: bytes.NewReader(data)}
+			sr := NewSegmentReader(cr)
+			var last Marker
+			for {
+				m, _, err := sr.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				last = m
+			}
+			if last != MarkerSOS {
+				t.Errorf("last segment %v, want SOS", last)
+			}
+			if sr.Offset() != want {
+				t.Errorf("consumed %d bytes, want %d", sr.Offset(), want)
+			}
+			// the entropy-coded data isn't read, bar the read-ahead of the buffer
+			if cr.n >= int64(len(data)) || cr.n > want+4096 {
+				t.Errorf("read %d bytes of %d, SOS ends at %d", cr.n, len(data), want)
+			}
+
+			// Next doesn't read past SOS once it returned io.EOF
+			if _, _, err := sr.Next(); err != io.EOF || sr.Offset() != want {
+				t.Errorf("Next() after SOS: %v, offset %d", err, sr.Offset())
+			}
+			rest, err := io.ReadAll(sr.Rest())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(rest, data[want:]) {
+				t.Errorf("Rest() returned %d bytes, want the %d following SOS", len(rest), len(data)-int(want))
+			}
+		})
+	}
+}
+
+func TestSegmentReaderReadScan(t *testing.T) {
+	// SOI, SOS, entropy-coded data with a stuffed zero and a restart
+	// marker, a second SOS, more data, EOI
+	data := []byte{
+		0xFF, 0xD8,
+		0xFF, 0xDA, 0x00, 0x03, 0x01,
+		0x12, 0xFF, 0x00, 0x34, 0xFF, 0xD0, 0x56,
+		0xFF, 0xDA, 0x00, 0x03, 0x02,
+		0x78,
+		0xFF, 0xD9,
+	}
+	sr := NewSegmentReader(bytes.NewReader(data))
+	sr.ReadScan = true
+	var markers []Marker
+	var offsets []int64
+	for {
+		m, _, err := sr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		markers = append(markers, m)
+		offsets = append(offsets, sr.Offset())
+	}
+	wantMarkers := []Marker{MarkerSOI, MarkerSOS, MarkerSOS, MarkerEOI}
+	wantOffsets := []int64{2, 7, 19, 22}
+	if len(markers) != len(wantMarkers) {
+		t.Fatalf("markers %v, want %v", markers, wantMarkers)
+	}
+	for i := range markers {
+		if markers[i] != wantMarkers[i] || offsets[i] != wantOffsets[i] {
+			t.Errorf("segment %d: %v at %d, want %v at %d", i, markers[i], offsets[i], wantMarkers[i], wantOffsets[i])
+		}
+	}
+
+	// without ReadScan, the same stream stops after the first SOS
+	sr = NewSegmentReader(bytes.NewReader(data))
+	for {
+		if _, _, err := sr.Next(); err != nil {
+			break
+		}
+	}
+	if sr.Offset() != 7 {
+		t.Errorf("consumed %d bytes, want 7", sr.Offset())
+	}
+}
+
+func TestSegmentReaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not a JPEG", []byte("GIF89a"), ErrNotJPEG},
+		{"truncated length", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00}, ErrTruncated},
+		{"invalid length", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01}, ErrTruncated},
+		{"truncated payload", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x10, 'E', 'x'}, ErrTruncated},
+	}
+	for _, tt := range tests {
+		sr := NewSegmentReader(bytes.NewReader(tt.data))
+		var err error
+		for err == nil {
+			_, _, err = sr.Next()
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Marker is the code following the 0xFF byte of a JPEG marker.
+type Marker byte
+
+// every marker starts with this byte
+const markerPrefix = 0xFF
+
+const (
+	// temporary private use in arithmetic coding, standalone
+	MarkerTEM Marker = 0x01
+
+	// Start Of Frame markers, non-differential, Huffman coding
+	MarkerSOF0 Marker = 0xC0 // baseline DCT
+	MarkerSOF1 Marker = 0xC1 // extended sequential DCT
+	MarkerSOF2 Marker = 0xC2 // progressive DCT
+	MarkerSOF3 Marker = 0xC3 // lossless (sequential)
+
+	// Define Huffman Table(s)
+	MarkerDHT Marker = 0xC4
+
+	// Start Of Frame markers, differential, Huffman coding
+	MarkerSOF5 Marker = 0xC5
+	MarkerSOF6 Marker = 0xC6
+	MarkerSOF7 Marker = 0xC7
+
+	// reserved for JPEG extensions
+	MarkerJPG Marker = 0xC8
+
+	// Start Of Frame markers, non-differential, arithmetic coding
+	MarkerSOF9  Marker = 0xC9
+	MarkerSOF10 Marker = 0xCA
+	MarkerSOF11 Marker = 0xCB
+
+	// Define Arithmetic Coding conditioning(s)
+	MarkerDAC Marker = 0xCC
+
+	// Start Of Frame markers, differential, arithmetic coding
+	MarkerSOF13 Marker = 0xCD
+	MarkerSOF14 Marker = 0xCE
+	MarkerSOF15 Marker = 0xCF
+
+	// ECS -> Entropy-Coded Segment
+	// restart markers, FF D0 - D7, which are just optional indicators in the middle of the ECS data.
+	MarkerRST0 Marker = 0xD0
+	MarkerRST7 Marker = 0xD7
+
+	// Magic Signature: at offset `0`, called Start of Image (SOI): 0xFFD8
+	MarkerSOI Marker = 0xD8
+
+	// terminator -> at, the end of the file, called End of Image (EOI): D9
+	MarkerEOI Marker = 0xD9
+
+	// Start Of Scan, followed by the entropy-coded data
+	MarkerSOS Marker = 0xDA
+
+	// Define Quantization Table(s)
+	MarkerDQT Marker = 0xDB
+
+	// Define Number of Lines
+	MarkerDNL Marker = 0xDC
+
+	// Define Restart Interval
+	MarkerDRI Marker = 0xDD
+
+	// Define Hierarchical Progression
+	MarkerDHP Marker = 0xDE
+
+	// EXPand reference component(s)
+	MarkerEXP Marker = 0xDF
+
+	// APPlication segments: APP0 JFIF, APP1 Exif/XMP, APP2 ICC, APP13 IPTC, ...
+	MarkerAPP0  Marker = 0xE0
+	MarkerAPP1  Marker = 0xE1
+	MarkerAPP2  Marker = 0xE2
+	MarkerAPP13 Marker = 0xED
+	MarkerAPP14 Marker = 0xEE
+	MarkerAPP15 Marker = 0xEF
+
+	// reserved for JPEG extensions, JPG0 - JPG13
+	MarkerJPG0  Marker = 0xF0
+	MarkerJPG13 Marker = 0xFD
+
+	// COMment
+	MarkerCOM Marker = 0xFE
+)
+
+var markerNames = map[Marker]string{
+	MarkerTEM: "TEM",
+	MarkerDHT: "DHT",
+	MarkerJPG: "JPG",
+	MarkerDAC: "DAC",
+	MarkerSOI: "SOI",
+	MarkerEOI: "EOI",
+	MarkerSOS: "SOS",
+	MarkerDQT: "DQT",
+	MarkerDNL: "DNL",
+	MarkerDRI: "DRI",
+	MarkerDHP: "DHP",
+	MarkerEXP: "EXP",
+	MarkerCOM: "COM",
+}
+
+func (m Marker) String() string {
+	switch {
+	case m.IsSOF():
+		return fmt.Sprintf("SOF%d", m-MarkerSOF0)
+	case m >= MarkerRST0 && m <= MarkerRST7:
+		return fmt.Sprintf("RST%d", m-MarkerRST0)
+	case m.IsAPP():
+		return fmt.Sprintf("APP%d", m-MarkerAPP0)
+	case m >= MarkerJPG0 && m <= MarkerJPG13:
+		return fmt.Sprintf("JPG%d", m-MarkerJPG0)
+	}
+	if s, ok := markerNames[m]; ok {
+		return s
+	}
+	return fmt.Sprintf("0x%02X", byte(m))
+}
+
+// IsSOF reports whether m is one of the 13 Start Of Frame markers.
+func (m Marker) IsSOF() bool {
+	return m >= MarkerSOF0 && m <= MarkerSOF15 && m != MarkerDHT && m != MarkerJPG && m != MarkerDAC
+}
+
+// IsAPP reports whether m is an APPn marker.
+func (m Marker) IsAPP() bool {
+	return m >= MarkerAPP0 && m <= MarkerAPP15
+}
+
+// IsStandalone checks if a marker code corresponds to a standalone marker (no length field).
+func (m Marker) IsStandalone() bool {
+	return m == MarkerSOI || m == MarkerEOI || m == MarkerTEM || (m >= MarkerRST0 && m <= MarkerRST7)
+}
+
+// Segment is a JPEG segment.
+type Segment interface {
+	Marker() Marker
+
+	// Payload returns the bytes following the length field,
+	// nil for standalone markers.
+	Payload() []byte
+}
+
+// SOI is the Start Of Image marker.
+type SOI struct{}
+
+func (SOI) Marker() Marker  { return MarkerSOI }
+func (SOI) Payload() []byte { return nil }
+
+// EOI is the End Of Image marker.
+type EOI struct{}
+
+func (EOI) Marker() Marker  { return MarkerEOI }
+func (EOI) Payload() []byte { return nil }
+
+// APPn is an APPlication segment. Its content is identified by
+// a signature, e.g. "Exif\0\0" or "JFIF\0".
+type APPn struct {
+	N    Marker // MarkerAPP0 - MarkerAPP15
+	Data []byte
+}
+
+func (a *APPn) Marker() Marker  { return a.N }
+func (a *APPn) Payload() []byte { return a.Data }
+
+// Is reports whether the segment data starts with signature.
+func (a *APPn) Is(signature string) bool {
+	return bytes.HasPrefix(a.Data, []byte(signature))
+}
+
+// Identifier returns the NUL terminated string the data starts with,
+// e.g. "Exif", "JFIF", "ICC_PROFILE" or "http://ns.adobe.com/xap/1.0/".
+func (a *APPn) Identifier() string {
+	if i := bytes.IndexByte(a.Data, 0); i >= 0 {
+		return string(a.Data[:i])
+	}
+	return ""
+}
+
+// COM is a comment segment.
+type COM struct {
+	Text []byte
+}
+
+func (c *COM) Marker() Marker  { return MarkerCOM }
+func (c *COM) Payload() []byte { return c.Text }
+
+// DQT defines quantization tables.
+type DQT struct {
+	Data []byte
+}
+
+func (d *DQT) Marker() Marker  { return MarkerDQT }
+func (d *DQT) Payload() []byte { return d.Data }
+
+// DHT defines Huffman tables.
+type DHT struct {
+	Data []byte
+}
+
+func (d *DHT) Marker() Marker  { return MarkerDHT }
+func (d *DHT) Payload() []byte { return d.Data }
+
+// FrameComponent is a component of a frame header.
+type FrameComponent struct {
+	ID byte
+
+	// horizontal (high nibble) and vertical (low nibble) sampling factors
+	Sampling byte
+
+	// quantization table selector
+	QuantTable byte
+}
+
+// SOFn is a Start Of Frame segment, the header of the image.
+type SOFn struct {
+	N          Marker // one of the 13 SOF markers
+	Precision  byte   // bits per sample
+	Height     uint16
+	Width      uint16
+	Components []FrameComponent
+	Data       []byte
+}
+
+func (s *SOFn) Marker() Marker  { return s.N }
+func (s *SOFn) Payload() []byte { return s.Data }
+
+// DRI defines the restart interval.
+type DRI struct {
+	// number of MCUs between RSTn markers, 0 disables them
+	Interval uint16
+	Data     []byte
+}
+
+func (d *DRI) Marker() Marker  { return MarkerDRI }
+func (d *DRI) Payload() []byte { return d.Data }
+
+// SOS is the Start Of Scan header, the entropy-coded data follows it.
+type SOS struct {
+	Data []byte
+}
+
+func (s *SOS) Marker() Marker  { return MarkerSOS }
+func (s *SOS) Payload() []byte { return s.Data }
+
+// RawSegment is any other segment (DNL, DAC, JPGn, ...).
+type RawSegment struct {
+	M    Marker
+	Data []byte
+}
+
+func (r *RawSegment) Marker() Marker  { return r.M }
+func (r *RawSegment) Payload() []byte { return r.Data }
+
+// NewSegment returns the typed segment of marker m.
+// Offsets reported in errors are relative to the start of the payload.
+func NewSegment(m Marker, payload []byte) (Segment, error) {
+	switch {
+	case m == MarkerSOI:
+		return SOI{}, nil
+	case m == MarkerEOI:
+		return EOI{}, nil
+	case m.IsAPP():
+		return &APPn{N: m, Data: payload}, nil
+	case m == MarkerCOM:
+		return &COM{Text: payload}, nil
+	case m == MarkerDQT:
+		return &DQT{Data: payload}, nil
+	case m == MarkerDHT:
+		return &DHT{Data: payload}, nil
+	case m == MarkerSOS:
+		return &SOS{Data: payload}, nil
+	case m == MarkerDRI:
+		if len(payload) < 2 {
+			return nil, &FormatError{Err: ErrTruncated, Msg: "DRI segment is too short"}
+		}
+		return &DRI{Interval: binary.BigEndian.Uint16(payload), Data: payload}, nil
+	case m.IsSOF():
+		// precision (1), height (2), width (2), component count (1), 3 bytes per component
+		if len(payload) < 6 || len(payload) < 6+3*int(payload[5]) {
+			return nil, &FormatError{Err: ErrTruncated, Msg: fmt.Sprintf("%s segment is too short", m)}
+		}
+		sof := &SOFn{
+			N:          m,
+			Precision:  payload[0],
+			Height:     binary.BigEndian.Uint16(payload[1:3]),
+			Width:      binary.BigEndian.Uint16(payload[3:5]),
+			Components: make([]FrameComponent, payload[5]),
+			Data:       payload,
+		}
+		for i := range sof.Components {
+			c := payload[6+3*i:]
+			sof.Components[i] = FrameComponent{ID: c[0], Sampling: c[1], QuantTable: c[2]}
+		}
+		return sof, nil
+	}
+	return &RawSegment{M: m, Data: payload}, nil
+}
+
+// SegmentBytes returns the bytes of s as found in a stream: the marker,
+// then the length field and payload of non standalone segments.
+func SegmentBytes(s Segment) []byte {
+	m := s.Marker()
+	if m.IsStandalone() {
+		return []byte{markerPrefix, byte(m)}
+	}
+	payload := s.Payload()
+	v := make([]byte, 4, 4+len(payload))
+	v[0], v[1] = markerPrefix, byte(m)
+	binary.BigEndian.PutUint16(v[2:], uint16(len(payload)+2))
+	return append(v, payload...)
+}
+
+// Signatures of well-known APPn segments.
+const (
+	SignatureExif = "Exif\x00\x00"
+	SignatureJFIF = "JFIF\x00"
+	SignatureJFXX = "JFXX\x00"
+	SignatureXMP  = "http://ns.adobe.com/xap/1.0/\x00"
+	SignatureICC  = "ICC_PROFILE\x00"
+	SignatureIPTC = "Photoshop 3.0\x00"
+)
+
+// Segments is a sequence of segments, in stream order.
+type Segments []Segment
+
+// ReadSegments reads the segments of a JPEG stream up to SOS included.
+func ReadSegments(r io.Reader) (Segments, error) {
+	var segs Segments
+	sr := NewSegmentReader(r)
+	for {
+		seg, err := sr.NextSegment()
+		if err == io.EOF {
+			return segs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read segments")
+		}
+		segs = append(segs, seg)
+	}
+}
+
+// Find returns the segments of marker m.
+func (ss Segments) Find(m Marker) []Segment {
+	var found []Segment
+	for _, s := range ss {
+		if s.Marker() == m {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// APP returns the first APPn segment of marker m whose data starts with signature.
+func (ss Segments) APP(m Marker, signature string) *APPn {
+	for _, s := range ss {
+		if a, ok := s.(*APPn); ok && a.N == m && a.Is(signature) {
+			return a
+		}
+	}
+	return nil
+}
+
+// Exif returns the Exif APP1 segment, or nil.
+func (ss Segments) Exif() *APPn {
+	return ss.APP(MarkerAPP1, SignatureExif)
+}
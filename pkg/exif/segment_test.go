@@ -0,0 +1,145 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadSegmentsSamples(t *testing.T) {
+	tests := []struct {
+		path    string
+		markers []Marker
+		sof     SOFn
+		app     []string // identifiers of the APPn segments
+	}{
+		{
+			path:    "../../DSCN0012.jpg",
+			markers: []Marker{MarkerSOI, MarkerAPP1, MarkerDQT, MarkerDHT, MarkerSOF0, MarkerAPP1, MarkerSOS},
+			sof: SOFn{N: MarkerSOF0, Precision: 8, Width: 640, Height: 480, Components: []FrameComponent{
+				{ID: 1, Sampling: 0x21, QuantTable: 0}, {ID: 2, Sampling: 0x11, QuantTable: 1}, {ID: 3, Sampling: 0x11, QuantTable: 1},
+			}},
+			app: []string{"Exif", "http://ns.adobe.com/xap/1.0/"},
+		},
+		{
+			path: "../../fujifilm-dx10.jpg",
+			markers: []Marker{MarkerSOI, MarkerAPP1, MarkerDQT, MarkerDQT, MarkerDQT, MarkerSOF0,
+				MarkerDHT, MarkerDHT, MarkerDHT, MarkerDHT, MarkerSOS},
+			sof: SOFn{N: MarkerSOF0, Precision: 8, Width: 1024, Height: 768, Components: []FrameComponent{
+				{ID: 1, Sampling: 0x21, QuantTable: 0}, {ID: 2, Sampling: 0x11, QuantTable: 1}, {ID: 3, Sampling: 0x11, QuantTable: 2},
+			}},
+			app: []string{"Exif"},
+		},
+		{
+			path:    "../../image.jpeg",
+			markers: []Marker{MarkerSOI, MarkerAPP0, MarkerDQT, MarkerDQT, MarkerSOF2, MarkerDHT, MarkerDHT, MarkerSOS},
+			sof: SOFn{N: MarkerSOF2, Precision: 8, Width: 1024, Height: 576, Components: []FrameComponent{
+				{ID: 1, Sampling: 0x22, QuantTable: 0}, {ID: 2, Sampling: 0x11, QuantTable: 1}, {ID: 3, Sampling: 0x11, QuantTable: 1},
+			}},
+			app: []string{"JFIF"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data, err := os.ReadFile(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			segs, err := ReadSegments(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var markers []Marker
+			var app []string
+			var raw []byte
+			for _, s := range segs {
+				markers = append(markers, s.Marker())
+				raw = append(raw, SegmentBytes(s)...)
+
+				// each marker has its type
+				var ok bool
+				switch m := s.Marker(); {
+				case m == MarkerSOI:
+					_, ok = s.(SOI)
+				case m.IsAPP():
+					var a *APPn
+					if a, ok = s.(*APPn); ok {
+						app = append(app, a.Identifier())
+					}
+				case m == MarkerDQT:
+					_, ok = s.(*DQT)
+				case m == MarkerDHT:
+					_, ok = s.(*DHT)
+				case m.IsSOF():
+					_, ok = s.(*SOFn)
+				case m == MarkerSOS:
+					_, ok = s.(*SOS)
+				}
+				if !ok {
+					t.Errorf("%v segment of type %T", s.Marker(), s)
+				}
+			}
+			if !reflect.DeepEqual(markers, tt.markers) {
+				t.Errorf("markers %v, want %v", markers, tt.markers)
+			}
+			if !reflect.DeepEqual(app, tt.app) {
+				t.Errorf("APPn identifiers %q, want %q", app, tt.app)
+			}
+
+			// the segments are the bytes up to the scan data
+			if want := data[:sosEnd(t, data)]; !bytes.Equal(raw, want) {
+				t.Errorf("segments give %d bytes, want %d", len(raw), len(want))
+			}
+
+			sofs := segs.Find(tt.sof.N)
+			if len(sofs) != 1 {
+				t.Fatalf("%d %v segments", len(sofs), tt.sof.N)
+			}
+			sof := *sofs[0].(*SOFn)
+			sof.Data = nil
+			if !reflect.DeepEqual(sof, tt.sof) {
+				t.Errorf("frame %+v, want %+v", sof, tt.sof)
+			}
+
+			if (segs.Exif() != nil) != (tt.app[0] == "Exif") {
+				t.Errorf("Exif() = %v", segs.Exif())
+			}
+			if a := segs.APP(MarkerAPP0, SignatureJFIF); (a != nil) != (tt.app[0] == "JFIF") {
+				t.Errorf("APP(APP0, JFIF) = %v", a)
+			}
+		})
+	}
+}
+
+func TestNewSegment(t *testing.T) {
+	s, err := NewSegment(MarkerDRI, []byte{0x01, 0x40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := s.(*DRI); !ok || d.Interval != 320 {
+		t.Errorf("DRI %#v", s)
+	}
+	if s, _ := NewSegment(MarkerDAC, []byte{1}); s.Marker() != MarkerDAC || reflect.TypeOf(s) != reflect.TypeOf(&RawSegment{}) {
+		t.Errorf("DAC %#v", s)
+	}
+	if s, _ := NewSegment(MarkerCOM, []byte("hello")); !bytes.Equal(SegmentBytes(s), []byte("\xFF\xFE\x00\x07hello")) {
+		t.Errorf("COM bytes %q", SegmentBytes(s))
+	}
+
+	for _, tt := range []struct {
+		m       Marker
+		payload []byte
+	}{
+		{MarkerDRI, []byte{1}},
+		{MarkerSOF0, []byte{8, 0, 1, 0, 1}},
+		// 3 components announced, 1 present
+		{MarkerSOF0, []byte{8, 0, 1, 0, 1, 3, 1, 0x11, 0}},
+	} {
+		if _, err := NewSegment(tt.m, tt.payload); !errors.Is(err, ErrTruncated) {
+			t.Errorf("%v %X: got %v, want ErrTruncated", tt.m, tt.payload, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package exif
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// ErrShift is returned by ParseShift for a malformed offset.
+var ErrShift = errors.New("malformed time offset")
+
+// ParseShift parses a signed time offset, a sequence of decimal numbers
+// with a unit: "+1h30m", "-2d", "1d12h", "-0.5s". The units are the ones of
+// time.ParseDuration ("ns", "us", "ms", "s", "m", "h") and "d" for 24 hours.
+func ParseShift(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	neg := strings.HasPrefix(str, "-")
+	if neg || strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+	if str == "" {
+		return 0, errors.Wrapf(ErrShift, "%q", s)
+	}
+
+	var d time.Duration
+	for str != "" {
+		// number, then unit
+		i := strings.IndexFunc(str, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, errors.Wrapf(ErrShift, "%q", s)
+		}
+		j := strings.IndexFunc(str[i:], func(r rune) bool { return r >= '0' && r <= '9' })
+		if j < 0 {
+			j = len(str) - i
+		}
+		num, unit := str[:i], str[i:i+j]
+		str = str[i+j:]
+
+		if unit == "d" {
+			days, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, errors.Wrapf(ErrShift, "%q", s)
+			}
+			d += time.Duration(days * 24 * float64(time.Hour))
+			continue
+		}
+		part, err := time.ParseDuration(num + unit)
+		if err != nil {
+			return 0, errors.Wrapf(ErrShift, "%q", s)
+		}
+		d += part
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// Shift adds d to the date and times of m: ModifyDate, DateTimeOriginal,
+// CreateDate (DateTimeDigitized) and GPSDateStamp with GPSTimeStamp. It
+// returns how many of them were shifted, absent ones being skipped.
+//
+// The time zone offsets are kept: they are still written when present and
+// not added otherwise. The fractions of seconds keep their number of digits,
+// or get more when d has a finer precision. On error m may be partially
+// shifted.
+func (m *Metadata) Shift(d time.Duration) (int, error) {
+	n := 0
+	for _, tags := range []DateTimeTags{ModifyDateTags, DateTimeOriginalTags, CreateDateTags} {
+		t, err := m.DateTime(tags, nil)
+		if errors.Is(err, ErrNoDateTime) {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+
+		e, _ := m.Get(tags.Offset)
+		offset, _ := e.Value.(string)
+		_, hasOffset, _ := parseOffset(offset)
+		e, _ = m.Get(tags.SubSec)
+		subSec, _ := e.Value.(string)
+		subSec = strings.TrimSpace(subSec)
+
+		t = t.Add(d)
+		if err := m.setDateTime(tags, t, hasOffset); err != nil {
+			return n, err
+		}
+		if subSec != "" {
+			// as many digits as before, "00" staying "00"
+			digits := strconv.Itoa(t.Nanosecond() + 1e9)[1:]
+			width := min(max(len(subSec), len(strings.TrimRight(digits, "0"))), len(digits))
+			if err := m.Set(tags.SubSec, digits[:width]); err != nil {
+				return n, err
+			}
+		}
+		n++
+	}
+
+	if e, ok := m.Get(tag.GPSDateStamp); ok {
+		s, _ := e.Value.(string)
+		date, err := time.Parse(gpsDateLayout, strings.TrimSpace(s))
+		e, _ = m.Get(tag.GPSTimeStamp)
+		hms, _ := e.Value.([]tag.Rational)
+		if err == nil && len(hms) == 3 && hms[0].IsValid() && hms[1].IsValid() && hms[2].IsValid() {
+			t := gpsDateTime(date, []float64{hms[0].Float64(), hms[1].Float64(), hms[2].Float64()})
+			stamp, date := gpsTimeStamp(t.Add(d))
+			if err := m.Set(tag.GPSTimeStamp, stamp); err != nil {
+				return n, err
+			}
+			if err := m.Set(tag.GPSDateStamp, date); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
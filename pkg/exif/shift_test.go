@@ -0,0 +1,165 @@
+package exif
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"exif/pkg/tag"
+)
+
+func TestParseShift(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+		err  bool
+	}{
+		{"1h", time.Hour, false},
+		{"+1h30m", time.Hour + 30*time.Minute, false},
+		{"-2d", -48 * time.Hour, false},
+		{"1d12h", 36 * time.Hour, false},
+		{"-0.5s", -500 * time.Millisecond, false},
+		{" +90s ", 90 * time.Second, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"250ms", 250 * time.Millisecond, false},
+
+		{"", 0, true},
+		{"+", 0, true},
+		{"-", 0, true},
+		{"--1h", 0, true},
+		{"+-1h", 0, true},
+		{"-+1h", 0, true},
+		{"++1h", 0, true},
+		{"1h-30m", 0, true},
+		{"1", 0, true},
+		{"h", 0, true},
+		{"1w", 0, true},
+		{"1..5h", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseShift(tt.s)
+		if tt.err {
+			if !errors.Is(err, ErrShift) {
+				t.Errorf("ParseShift(%q) = %v, %v, want ErrShift", tt.s, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseShift(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	tests := []struct {
+		name            string
+		set             map[tag.Tag]any
+		d               time.Duration
+		n               int
+		want            map[tag.Tag]any
+		absent          []tag.Tag
+		exifIFD, gpsIFD bool
+	}{
+		{
+			name: "year boundary",
+			set: map[tag.Tag]any{
+				tag.DateTimeOriginal:   "2019:12:31 23:30:00",
+				tag.OffsetTimeOriginal: "+01:00",
+			},
+			d: time.Hour,
+			n: 1,
+			want: map[tag.Tag]any{
+				tag.DateTimeOriginal:   "2020:01:01 00:30:00",
+				tag.OffsetTimeOriginal: "+01:00",
+			},
+			exifIFD: true,
+		},
+		{
+			name: "month boundary backwards, leap year",
+			set: map[tag.Tag]any{
+				tag.CreateDate:          "2020:03:01 00:10:00",
+				tag.SubSecTimeDigitized: "50",
+			},
+			d: -20 * time.Minute,
+			n: 1,
+			want: map[tag.Tag]any{
+				tag.CreateDate:          "2020:02:29 23:50:00",
+				tag.SubSecTimeDigitized: "50",
+			},
+			absent:  []tag.Tag{tag.OffsetTimeDigitized},
+			exifIFD: true,
+		},
+		{
+			name: "fractions of seconds",
+			set: map[tag.Tag]any{
+				tag.DateTimeOriginal:   "2021:04:30 23:59:59",
+				tag.SubSecTimeOriginal: "00",
+			},
+			d: 1500 * time.Millisecond,
+			n: 1,
+			want: map[tag.Tag]any{
+				tag.DateTimeOriginal:   "2021:05:01 00:00:00",
+				tag.SubSecTimeOriginal: "50",
+			},
+			exifIFD: true,
+		},
+		{
+			// no Exif IFD is created for the absent offset
+			name: "ModifyDate only",
+			set: map[tag.Tag]any{
+				tag.ModifyDate: "2021:01:31 12:00:00",
+			},
+			d: 24 * time.Hour,
+			n: 1,
+			want: map[tag.Tag]any{
+				tag.ModifyDate: "2021:02:01 12:00:00",
+			},
+			absent: []tag.Tag{tag.OffsetTime},
+		},
+		{
+			name: "GPS date and time",
+			set: map[tag.Tag]any{
+				tag.DateTimeOriginal: "2021:12:31 22:00:00",
+				tag.GPSDateStamp:     "2021:12:31",
+				tag.GPSTimeStamp:     []tag.Rational{{Num: 21, Den: 1}, {Num: 0, Den: 1}, {Num: 12500, Den: 1000}},
+			},
+			d: 3 * time.Hour,
+			n: 2,
+			want: map[tag.Tag]any{
+				tag.DateTimeOriginal: "2022:01:01 01:00:00",
+				tag.GPSDateStamp:     "2022:01:01",
+				tag.GPSTimeStamp:     []tag.Rational{{Num: 0, Den: 1}, {Num: 0, Den: 1}, {Num: 12500, Den: 1000}},
+			},
+			exifIFD: true,
+			gpsIFD:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Metadata{}
+			for tg, v := range tt.set {
+				if err := m.Set(tg, v); err != nil {
+					t.Fatalf("Set(%v): %v", tg, err)
+				}
+			}
+			n, err := m.Shift(tt.d)
+			if err != nil || n != tt.n {
+				t.Fatalf("Shift(%v) = %d, %v, want %d", tt.d, n, err, tt.n)
+			}
+			for tg, v := range tt.want {
+				if e, _ := m.Get(tg); !reflect.DeepEqual(e.Value, v) {
+					t.Errorf("%v = %#v, want %#v", tg, e.Value, v)
+				}
+			}
+			for _, tg := range tt.absent {
+				if e, ok := m.Get(tg); ok {
+					t.Errorf("%v = %#v, want none", tg, e.Value)
+				}
+			}
+			if (m.Exif != nil) != tt.exifIFD || (m.GPS != nil) != tt.gpsIFD {
+				t.Errorf("Exif IFD %t, GPS IFD %t, want %t, %t", m.Exif != nil, m.GPS != nil, tt.exifIFD, tt.gpsIFD)
+			}
+		})
+	}
+}
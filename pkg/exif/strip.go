@@ -0,0 +1,181 @@
+package exif
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// SignatureXMPExtension starts the APP1 segments of extended XMP, the part
+// of an XMP packet that doesn't fit in the main XMP segment.
+const SignatureXMPExtension = "http://ns.adobe.com/xmp/extension/\x00"
+
+// StripOptions selects the metadata removed by Strip.
+type StripOptions struct {
+	// the whole Exif APP1 segment
+	Exif bool
+
+	// the GPS IFD
+	GPS bool
+
+	// SerialNumber, CameraSerialNumber and LensSerialNumber
+	Serials bool
+
+	// OwnerName and Artist
+	Owner bool
+
+	// MakerNote, the manufacturer specific data
+	MakerNotes bool
+
+	// IFD1 and its thumbnail, and the JFXX APP0 thumbnail
+	Thumbnail bool
+
+	// XMP APP1 segments
+	XMP bool
+
+	// IPTC (Photoshop) APP13 segments
+	IPTC bool
+
+	// COM segments
+	Comments bool
+
+	// when not empty, the tags of IFD0, Exif, GPS and Interop that are kept,
+	// every other one is removed; IFDs left empty are removed. IFD1 only
+	// describes the thumbnail and is left as is, see Thumbnail
+	Keep []tag.Tag
+}
+
+// StripPrivacy removes location and identifying data: GPS, serial numbers,
+// owner, maker notes, thumbnails (which may show what was cropped), XMP,
+// IPTC and comments. The camera settings are kept.
+var StripPrivacy = StripOptions{
+	GPS:        true,
+	Serials:    true,
+	Owner:      true,
+	MakerNotes: true,
+	Thumbnail:  true,
+	XMP:        true,
+	IPTC:       true,
+	Comments:   true,
+}
+
+// tags removed by StripOptions.Serials and StripOptions.Owner
+var (
+	serialTags = []tag.Tag{tag.SerialNumber, tag.CameraSerialNumber, tag.LensSerialNumber}
+	ownerTags  = []tag.Tag{tag.OwnerName, tag.Artist}
+)
+
+// Strip removes the tags and IFDs selected by opts from m. Removing the whole
+// Exif APP1 and the other segments is done by the Strip function.
+func (m *Metadata) Strip(opts StripOptions) {
+	if m == nil {
+		return
+	}
+
+	if opts.GPS {
+		m.Delete(tag.GPSInfo)
+	}
+	if opts.Serials {
+		for _, t := range serialTags {
+			m.Delete(t)
+		}
+	}
+	if opts.Owner {
+		for _, t := range ownerTags {
+			m.Delete(t)
+		}
+	}
+	if opts.MakerNotes {
+		m.Delete(tag.MakerNote)
+	}
+	if opts.Thumbnail {
+		m.IFD1 = nil
+		if len(m.Chain) > 1 {
+			m.Chain = m.Chain[:1]
+		}
+		m.JFXX = nil
+	}
+
+	if len(opts.Keep) > 0 {
+		keep := make(map[tag.Key]bool, len(opts.Keep))
+		for _, t := range opts.Keep {
+			keep[t.Key()] = true
+		}
+		for _, p := range []**IFD{&m.IFD0, &m.Exif, &m.GPS, &m.Interop} {
+			if *p == nil {
+				continue
+			}
+			ifd := *p
+			entries := ifd.Entries[:0]
+			for _, e := range ifd.Entries {
+				if keep[tag.Key{IFD: ifd.Namespace, ID: e.TagID}] {
+					entries = append(entries, e)
+				}
+			}
+			ifd.Entries = entries
+		}
+
+		// sub-IFDs left empty aren't linked anymore
+		if m.Interop != nil && len(m.Interop.Entries) == 0 {
+			m.Interop = nil
+		}
+		if m.Exif != nil && len(m.Exif.Entries) == 0 && m.Interop == nil {
+			m.Exif = nil
+		}
+		if m.GPS != nil && len(m.GPS.Entries) == 0 {
+			m.GPS = nil
+		}
+	}
+}
+
+// Strip copies the JPEG stream of src to dst without the metadata selected
+// by opts. The Exif APP1 segment is re-encoded without the stripped tags, the
+// other segments and the image data are copied as is.
+func Strip(dst io.Writer, src io.Reader, opts StripOptions) error {
+	return rewriteJPEG(dst, src, func(segs Segments) (Segments, error) {
+		var app1 *APPn
+		if exif := segs.Exif(); exif != nil && !opts.Exif {
+			meta, err := ParseAPP1(SegmentBytes(exif))
+			if err != nil {
+				return nil, err
+			}
+			meta.Strip(opts)
+			if meta.IFD0 != nil {
+				payload, err := meta.EncodeAPP1()
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to encode metadata")
+				}
+				app1 = &APPn{N: MarkerAPP1, Data: payload}
+			}
+		}
+		segs = segs.WithExif(app1)
+
+		out := segs[:0]
+		for _, s := range segs {
+			if !opts.strips(s) {
+				out = append(out, s)
+			}
+		}
+		return out, nil
+	})
+}
+
+// strips reports whether segment s, other than Exif APP1, is removed.
+func (opts StripOptions) strips(s Segment) bool {
+	switch s := s.(type) {
+	case *APPn:
+		switch {
+		case s.N == MarkerAPP0 && s.Is(SignatureJFXX):
+			return opts.Thumbnail
+		case s.N == MarkerAPP1 && (s.Is(SignatureXMP) || s.Is(SignatureXMPExtension)):
+			return opts.XMP
+		case s.N == MarkerAPP13 && s.Is(SignatureIPTC):
+			return opts.IPTC
+		}
+	case *COM:
+		return opts.Comments
+	}
+	return false
+}
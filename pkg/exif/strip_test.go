@@ -0,0 +1,166 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"exif/pkg/tag"
+)
+
+// privateJPEG returns DSCN0012.jpg, which has GPS data, a MakerNote and
+// a thumbnail, with a SerialNumber, an OwnerName, an XMP and a COM segment.
+func privateJPEG(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("../../DSCN0012.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set(tag.SerialNumber, "1234567"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set(tag.OwnerName, "Jane Doe"); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := m.EncodeAPP1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = rewriteJPEG(&buf, bytes.NewReader(data), func(segs Segments) (Segments, error) {
+		segs = segs.WithExif(&APPn{N: MarkerAPP1, Data: payload})
+		out := Segments{segs[0], segs[1],
+			&APPn{N: MarkerAPP1, Data: []byte(SignatureXMP + "<x:xmpmeta xmlns:x='adobe:ns:meta/'/>")},
+			&COM{Text: []byte("taken at home")},
+		}
+		return append(out, segs[2:]...), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// scanData returns the data following SOS.
+func scanData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	sr := NewSegmentReader(bytes.NewReader(data))
+	for {
+		if _, err := sr.NextSegment(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	rest, err := io.ReadAll(sr.Rest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rest
+}
+
+func TestStripPrivacy(t *testing.T) {
+	in := privateJPEG(t)
+	var buf bytes.Buffer
+	if err := Strip(&buf, bytes.NewReader(in), StripPrivacy); err != nil {
+		t.Fatal(err)
+	}
+
+	segs, err := ReadSegments(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segs.APP(MarkerAPP1, SignatureXMP) != nil {
+		t.Error("XMP segment kept")
+	}
+	if len(segs.Find(MarkerCOM)) > 0 {
+		t.Error("COM segment kept")
+	}
+	if !bytes.Equal(scanData(t, buf.Bytes()), scanData(t, in)) {
+		t.Error("scan data differs")
+	}
+
+	m, err := Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GPS != nil {
+		t.Error("GPS IFD kept")
+	}
+	if m.IFD1 != nil {
+		t.Error("IFD1 kept")
+	}
+	if _, err := m.Thumbnail(); !errors.Is(err, ErrNoThumbnail) {
+		t.Errorf("Thumbnail() error %v, want ErrNoThumbnail", err)
+	}
+	for _, tg := range []tag.Tag{tag.GPSInfo, tag.SerialNumber, tag.OwnerName, tag.MakerNote} {
+		if e, ok := m.Get(tg); ok {
+			t.Errorf("%v kept: %v", tg, e.Value)
+		}
+	}
+	// camera settings are kept
+	for _, tg := range []tag.Tag{tag.Make, tag.Model, tag.ExposureTime, tag.DateTimeOriginal} {
+		if _, ok := m.Get(tg); !ok {
+			t.Errorf("%v removed", tg)
+		}
+	}
+}
+
+func TestStripKeep(t *testing.T) {
+	in := privateJPEG(t)
+	var buf bytes.Buffer
+	opts := StripOptions{Keep: []tag.Tag{tag.Make, tag.Model, tag.ExposureTime, tag.FNumber}}
+	if err := Strip(&buf, bytes.NewReader(in), opts); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(scanData(t, buf.Bytes()), scanData(t, in)) {
+		t.Error("scan data differs")
+	}
+
+	m, err := Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := func(ifd *IFD) []string {
+		var s []string
+		for _, e := range ifd.Entries {
+			if !pointerTags[ifd.Namespace][e.TagID] {
+				s = append(s, e.Name)
+			}
+		}
+		return s
+	}
+	if got := names(m.IFD0); len(got) != 2 || got[0] != "Make" || got[1] != "Model" {
+		t.Errorf("IFD0 tags %v, want [Make Model]", got)
+	}
+	if m.Exif == nil {
+		t.Fatal("Exif IFD removed")
+	}
+	if got := names(m.Exif); len(got) != 2 || got[0] != "ExposureTime" || got[1] != "FNumber" {
+		t.Errorf("Exif tags %v, want [ExposureTime FNumber]", got)
+	}
+	// sub-IFDs left empty are removed
+	if m.GPS != nil || m.Interop != nil {
+		t.Errorf("GPS IFD %v, Interop IFD %v, want none", m.GPS != nil, m.Interop != nil)
+	}
+	// Keep doesn't apply to IFD1
+	if _, err := m.Thumbnail(); err != nil {
+		t.Errorf("thumbnail removed: %v", err)
+	}
+
+	// other segments are kept
+	segs, err := ReadSegments(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segs.APP(MarkerAPP1, SignatureXMP) == nil || len(segs.Find(MarkerCOM)) != 1 {
+		t.Error("XMP or COM segment removed")
+	}
+}
@@ -0,0 +1,152 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// Thumbnail is the preview image described by IFD1.
+type Thumbnail struct {
+	// tag.CompressionNone for uncompressed strips, any other value
+	// (tag.CompressionOldJPEG usually) for a JPEG stream (0xffd8...ffd9)
+	Compression tag.CompressionValue
+
+	// dimensions of uncompressed thumbnails, 0 for JPEG ones
+	Width, Height int
+
+	// PhotometricInterpretation of uncompressed thumbnails (2 for RGB, 6 for YCbCr)
+	Photometric uint16
+
+	// JPEG stream, or the strips concatenated for uncompressed thumbnails
+	Data []byte
+}
+
+// Thumbnail returns the thumbnail pointed to by IFD1, either the JPEG stream
+// of ThumbnailOffset/ThumbnailLength or the StripOffsets/StripByteCounts strips
+// of an uncompressed thumbnail. It returns ErrNoThumbnail when there is none.
+func (m *Metadata) Thumbnail() (*Thumbnail, error) {
+	if m == nil || m.IFD1 == nil || m.tiff == nil {
+		return nil, ErrNoThumbnail
+	}
+
+	ifd := m.IFD1
+	th := &Thumbnail{Compression: tag.CompressionOldJPEG}
+	if e, ok := ifd.Get(uint16(tag.Compression)); ok {
+		th.Compression = tag.CompressionValue(firstUint(e.Value))
+	}
+
+	// ranges returns the data at each offsets/lengths pair
+	ranges := func(offsets, lengths IfdEntry) ([]byte, error) {
+		offs, lens := uints(offsets.Value), uints(lengths.Value)
+		if len(offs) != len(lens) {
+			return nil, formatErr(ErrTruncated, "IFD1", lengths.Offset, "%d offsets for %d lengths", len(offs), len(lens))
+		}
+
+		var data []byte
+		for i, off := range offs {
+			if !m.tiff.fits(off, lens[i]) {
+				return nil, formatErr(ErrOffsetOutOfRange, "IFD1", off, "thumbnail data of %d bytes overruns data (%d)", lens[i], m.tiff.size)
+			}
+			strip, err := m.tiff.bytes(off, lens[i])
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, strip...)
+		}
+		return data, nil
+	}
+
+	var err error
+	switch th.Compression {
+	case tag.CompressionNone:
+		offsets, ok1 := ifd.Get(uint16(tag.StripOffsets))
+		lengths, ok2 := ifd.Get(uint16(tag.StripByteCounts))
+		if !ok1 || !ok2 {
+			return nil, ErrNoThumbnail
+		}
+		if e, ok := ifd.Get(uint16(tag.ImageWidth)); ok {
+			th.Width = int(firstUint(e.Value))
+		}
+		if e, ok := ifd.Get(uint16(tag.ImageHeight)); ok {
+			th.Height = int(firstUint(e.Value))
+		}
+		if e, ok := ifd.Get(uint16(tag.PhotometricInterpretation)); ok {
+			th.Photometric = uint16(firstUint(e.Value))
+		}
+		th.Data, err = ranges(offsets, lengths)
+
+	default:
+		offset, ok1 := ifd.Get(uint16(tag.ThumbnailOffset))
+		length, ok2 := ifd.Get(uint16(tag.ThumbnailLength))
+		if !ok1 || !ok2 {
+			return nil, ErrNoThumbnail
+		}
+		th.Data, err = ranges(offset, length)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return th, nil
+}
+
+// Image decodes the thumbnail. Uncompressed thumbnails are only supported
+// as 8-bit RGB.
+func (t *Thumbnail) Image() (image.Image, error) {
+	if t.Compression != tag.CompressionNone {
+		img, err := jpeg.Decode(bytes.NewReader(t.Data))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode JPEG thumbnail")
+		}
+		return img, nil
+	}
+
+	if t.Photometric != 2 {
+		return nil, fmt.Errorf("unsupported photometric interpretation %d", t.Photometric)
+	}
+	if len(t.Data) < 3*t.Width*t.Height {
+		return nil, fmt.Errorf("want %d bytes of RGB thumbnail, have %d", 3*t.Width*t.Height, len(t.Data))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, t.Width, t.Height))
+	for i := range t.Width * t.Height {
+		p := t.Data[i*3 : i*3+3]
+		img.Pix[i*4], img.Pix[i*4+1], img.Pix[i*4+2], img.Pix[i*4+3] = p[0], p[1], p[2], 0xFF
+	}
+	return img, nil
+}
+
+// uints returns the values of a SHORT, LONG or LONG8 entry.
+func uints(v any) []uint64 {
+	switch v := v.(type) {
+	case []uint64:
+		return v
+	case []uint32:
+		out := make([]uint64, len(v))
+		for i, x := range v {
+			out[i] = uint64(x)
+		}
+		return out
+	case []uint16:
+		out := make([]uint64, len(v))
+		for i, x := range v {
+			out[i] = uint64(x)
+		}
+		return out
+	}
+	return nil
+}
+
+// firstUint returns the first value of a SHORT, LONG or LONG8 entry, or 0.
+func firstUint(v any) uint64 {
+	if u := uints(v); len(u) > 0 {
+		return u[0]
+	}
+	return 0
+}
@@ -0,0 +1,211 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"exif/pkg/tag"
+)
+
+// byte order mark and magic number of classic TIFF and BigTIFF files
+var (
+	tiffSignatureII    = []byte{'I', 'I', 42, 0}
+	tiffSignatureMM    = []byte{'M', 'M', 0, 42}
+	bigTIFFSignatureII = []byte{'I', 'I', 43, 0}
+	bigTIFFSignatureMM = []byte{'M', 'M', 0, 43}
+)
+
+// magic numbers following the byte order mark
+const (
+	tiffMagic    = 42
+	bigTIFFMagic = 43
+)
+
+// hasTIFFSignature reports whether sig starts with a TIFF or BigTIFF header.
+func hasTIFFSignature(sig []byte) bool {
+	for _, s := range [][]byte{tiffSignatureII, tiffSignatureMM, bigTIFFSignatureII, bigTIFFSignatureMM} {
+		if bytes.HasPrefix(sig, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTIFF reports whether the stream of br starts with a TIFF header.
+func isTIFF(br *bufio.Reader) bool {
+	sig, err := br.Peek(4)
+	if err != nil {
+		return false
+	}
+	return hasTIFFSignature(sig)
+}
+
+// tiffSource gives access to TIFF data, offsets are relative to the TIFF header.
+type tiffSource struct {
+	r    io.ReaderAt
+	size int64
+	bo   binary.ByteOrder
+
+	// BigTIFF: 8 bytes counts and offsets, 20 bytes entries
+	big bool
+}
+
+// fits reports whether the n bytes at off are within the data.
+func (t *tiffSource) fits(off, n uint64) bool {
+	return off <= uint64(t.size) && n <= uint64(t.size)-off
+}
+
+// bytes reads the n bytes at off, which must fit in the data.
+func (t *tiffSource) bytes(off, n uint64) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := t.r.ReadAt(buf, int64(off)); err != nil && !(err == io.EOF && off+n == uint64(t.size)) {
+		return nil, errors.Wrap(err, "failed to read TIFF data")
+	}
+	return buf, nil
+}
+
+// DecodeTIFF reads a whole TIFF file from r and returns its metadata.
+// TIFF offsets may point anywhere in the file, so unlike JPEG the
+// complete stream is read; use DecodeTIFFAt for large files.
+func DecodeTIFF(r io.Reader) (*Metadata, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image")
+	}
+	return ParseTIFF(data)
+}
+
+// ParseTIFF parses TIFF data starting with its header ('II' or 'MM',
+// magic number, offset of IFD0) and returns the entries of every IFD.
+func ParseTIFF(data []byte) (*Metadata, error) {
+	return DecodeTIFFAt(bytes.NewReader(data), int64(len(data)))
+}
+
+// DecodeTIFFAt reads the IFDs of the TIFF or BigTIFF file of size bytes
+// in r, without loading the image data. The returned Metadata keeps r
+// to read the thumbnail, which must stay readable until then.
+func DecodeTIFFAt(r io.ReaderAt, size int64) (*Metadata, error) {
+	if size < 8 {
+		return nil, &FormatError{Err: ErrTruncated, Msg: fmt.Sprintf("%d bytes are too short for a TIFF header", size)}
+	}
+
+	var bom [2]byte
+	if _, err := r.ReadAt(bom[:], 0); err != nil {
+		return nil, errors.Wrap(err, "failed to read TIFF header")
+	}
+	endian, ok := EndianTypeFromStr[string(bom[:])]
+	if !ok {
+		return nil, &FormatError{Err: ErrByteOrder, Msg: fmt.Sprintf("got %q", bom[:])}
+	}
+
+	return readTIFF(&tiffSource{r: r, size: size, bo: endian.ByteOrder()}, endian)
+}
+
+// readTIFF reads the TIFF header of t and walks the IFD tree:
+// the chain of IFD0, IFD1, ... linked by their next pointers, the Exif
+// and GPS sub-IFDs of IFD0 and the Interoperability IFD linked by the
+// Exif sub-IFD. Only the header and IFD0 are required: the errors of the
+// other IFDs go to Metadata.Warnings, the sub-IFD being left nil and the
+// chain stopping at the IFD that failed.
+//
+// Classic TIFF headers are 'II' or 'MM', 42 and the 4 bytes offset of IFD0.
+// BigTIFF headers are 'II' or 'MM', 43, the offsets size (always 8),
+// 2 zero bytes and the 8 bytes offset of IFD0.
+func readTIFF(t *tiffSource, endian EndianType) (*Metadata, error) {
+	bo := t.bo
+	header, err := t.bytes(0, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstIFDOffset uint64
+	switch magic := bo.Uint16(header[2:4]); magic {
+	case tiffMagic:
+		firstIFDOffset = uint64(bo.Uint32(header[4:8]))
+	case bigTIFFMagic:
+		if !t.fits(0, 16) {
+			return nil, &FormatError{Err: ErrTruncated, Msg: fmt.Sprintf("%d bytes are too short for a BigTIFF header", t.size)}
+		}
+		if header, err = t.bytes(0, 16); err != nil {
+			return nil, err
+		}
+		if size, zero := bo.Uint16(header[4:6]), bo.Uint16(header[6:8]); size != 8 || zero != 0 {
+			return nil, &FormatError{Err: ErrTIFFMagic, Offset: 4, Msg: fmt.Sprintf("BigTIFF offsets of %d bytes, then %d", size, zero)}
+		}
+		t.big = true
+		firstIFDOffset = bo.Uint64(header[8:16])
+	default:
+		return nil, &FormatError{Err: ErrTIFFMagic, Offset: 2, Msg: fmt.Sprintf("got %d", magic)}
+	}
+
+	meta := &Metadata{ByteOrder: endian, tiff: t}
+	if meta.IFD0, err = parseIFD(t, firstIFDOffset, tag.IFDTIFF, "IFD0"); err != nil {
+		return nil, err
+	}
+
+	// subIFD parses the IFD pointed to by the pointer tag id of parent,
+	// it returns a nil IFD when the pointer is absent.
+	subIFD := func(parent *IFD, parentPath string, id tag.Exif, ns tag.IFD, path string) (*IFD, error) {
+		entry, found := parent.Get(uint16(id))
+		if !found {
+			return nil, nil
+		}
+
+		// the value is the offset: a LONG (or IFD) in classic TIFF,
+		// a LONG8 (or IFD8) in BigTIFF
+		var off []uint64
+		switch v := entry.Value.(type) {
+		case []uint32, []uint64:
+			off = uints(v)
+		}
+		if len(off) != 1 {
+			return nil, formatErr(ErrInvalidPointer, parentPath, entry.Offset, "unexpected %s value: %T of %d values", id, entry.Value, entry.Count)
+		}
+		return parseIFD(t, off[0], ns, path)
+	}
+
+	// a bad sub-IFD or IFD of the chain is left out with a warning,
+	// the other IFDs are still read
+	warn := func(ifd **IFD, parsed *IFD, err error) {
+		if err != nil {
+			meta.Warnings = append(meta.Warnings, err)
+			return
+		}
+		*ifd = parsed
+	}
+	exif, err := subIFD(meta.IFD0, "IFD0", tag.ExifOffset, tag.IFDExif, "IFD0/Exif")
+	warn(&meta.Exif, exif, err)
+	gps, err := subIFD(meta.IFD0, "IFD0", tag.GPSInfo, tag.IFDGPS, "IFD0/GPS")
+	warn(&meta.GPS, gps, err)
+	interop, err := subIFD(meta.Exif, "IFD0/Exif", tag.InteropOffset, tag.IFDInterop, "IFD0/Exif/Interop")
+	warn(&meta.Interop, interop, err)
+
+	// the rest of the chain, guarding against IFDs pointing back
+	meta.Chain = []*IFD{meta.IFD0}
+	visited := map[uint64]bool{meta.IFD0.Offset: true}
+	for last := meta.IFD0; last.Next != 0; {
+		path := fmt.Sprintf("IFD%d", len(meta.Chain))
+		if visited[last.Next] {
+			meta.Warnings = append(meta.Warnings, formatErr(ErrIFDLoop, path, last.Next, "IFD%d points back to an IFD already read", len(meta.Chain)-1))
+			break
+		}
+		visited[last.Next] = true
+
+		next, err := parseIFD(t, last.Next, tag.IFDTIFF, path)
+		if err != nil {
+			meta.Warnings = append(meta.Warnings, err)
+			break
+		}
+		meta.Chain = append(meta.Chain, next)
+		last = next
+	}
+	if len(meta.Chain) > 1 {
+		meta.IFD1 = meta.Chain[1]
+	}
+	return meta, nil
+}
@@ -0,0 +1,165 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	"exif/pkg/tag"
+)
+
+// TestReadTIFFBadSubIFD checks a bad pointer to a sub-IFD or to IFD1 leaves
+// the other IFDs readable.
+func TestReadTIFFBadSubIFD(t *testing.T) {
+	bo := binary.LittleEndian
+	data := bigTIFF(LittleEndian,
+		[]bigEntry{
+			{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")},
+			{uint16(tag.GPSInfo), uint16(tag.IFD8), 1, bo.AppendUint64(nil, 1<<20)},
+		},
+		[]bigEntry{
+			{uint16(tag.ExposureTime), uint16(tag.RATIONAL), 1, bo.AppendUint32(bo.AppendUint32(nil, 1), 250)},
+			{uint16(tag.InteropOffset), uint16(tag.SHORT), 2, []byte{1, 0, 2, 0}},
+		})
+	// IFD0 (3 entries with ExifOffset) points to IFD1 past the end
+	bo.PutUint64(data[16+8+3*20:], 1<<20)
+
+	m, err := ParseTIFF(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := m.Get(tag.Make); e.Value != "ACME" {
+		t.Errorf("Make %#v", e.Value)
+	}
+	if e, _ := m.Get(tag.ExposureTime); e.Value == nil {
+		t.Error("Exif IFD not read")
+	}
+	if m.GPS != nil || m.Interop != nil || m.IFD1 != nil || len(m.Chain) != 1 {
+		t.Errorf("GPS %v, Interop %v, IFD1 %v, %d IFDs in the chain", m.GPS, m.Interop, m.IFD1, len(m.Chain))
+	}
+
+	if len(m.Warnings) != 3 {
+		t.Fatalf("warnings %v, want 3", m.Warnings)
+	}
+	for i, want := range []error{ErrTruncated, ErrInvalidPointer, ErrTruncated} {
+		var fe *FormatError
+		if !errors.Is(m.Warnings[i], want) || !errors.As(m.Warnings[i], &fe) {
+			t.Errorf("warning %d: got %v, want a FormatError of %v", i, m.Warnings[i], want)
+		}
+	}
+}
+
+func TestReadBigTIFF(t *testing.T) {
+	for _, order := range []EndianType{LittleEndian, BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			bo := order.ByteOrder().(binary.AppendByteOrder)
+			model := []byte("a model name longer than 8 bytes\x00")
+			ifd0 := func(modelOffset uint64) []bigEntry {
+				return []bigEntry{
+					{uint16(tag.ImageWidth), uint16(tag.LONG8), 1, bo.AppendUint64(nil, 1<<33)},
+					{uint16(tag.Make), uint16(tag.ASCII), 8, []byte("ACMEcam\x00")},
+					{uint16(tag.Model), uint16(tag.ASCII), uint64(len(model)), bo.AppendUint64(nil, modelOffset)},
+				}
+			}
+			exif := []bigEntry{
+				{uint16(tag.ExposureTime), uint16(tag.RATIONAL), 1, bo.AppendUint32(bo.AppendUint32(nil, 1), 250)},
+			}
+			// the Model value follows the IFDs
+			data := bigTIFF(order, ifd0(0), exif)
+			data = append(bigTIFF(order, ifd0(uint64(len(data))), exif), model...)
+
+			if magic := order.ByteOrder().Uint16(data[2:4]); magic != bigTIFFMagic {
+				t.Fatalf("magic %d, want %d", magic, bigTIFFMagic)
+			}
+			m, err := ParseTIFF(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.ByteOrder != order {
+				t.Errorf("ByteOrder %v, want %v", m.ByteOrder, order)
+			}
+			if m.IFD0.Offset != 16 {
+				t.Errorf("IFD0 at %d, want 16", m.IFD0.Offset)
+			}
+			for _, tt := range []struct {
+				tag   tag.Tag
+				value any
+			}{
+				{tag.ImageWidth, []uint64{1 << 33}},
+				{tag.Make, "ACMEcam"},
+				{tag.Model, "a model name longer than 8 bytes"},
+				{tag.ExposureTime, []tag.Rational{{Num: 1, Den: 250}}},
+			} {
+				if e, _ := m.Get(tt.tag); !reflect.DeepEqual(e.Value, tt.value) {
+					t.Errorf("%v = %#v, want %#v", tt.tag, e.Value, tt.value)
+				}
+			}
+
+			// 20 bytes entries: the ones of IFD0 follow its 8 bytes count
+			for i, e := range m.IFD0.Entries {
+				if want := uint64(16 + 8 + 20*i); e.Offset != want {
+					t.Errorf("entry %d at %d, want %d", i, e.Offset, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadBigTIFFPointers(t *testing.T) {
+	bo := binary.LittleEndian
+	exif := []bigEntry{{uint16(tag.ExposureTime), uint16(tag.RATIONAL), 1, bo.AppendUint32(bo.AppendUint32(nil, 1), 250)}}
+	for _, tp := range []tag.Type{tag.IFD8, tag.LONG8} {
+		t.Run(tp.String(), func(t *testing.T) {
+			data := bigTIFF(LittleEndian, []bigEntry{{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")}}, exif)
+			// ExifOffset is the last entry of IFD0
+			bo.PutUint16(data[16+8+20+2:], uint16(tp))
+
+			// IFD1, a copy of IFD0 without ExifOffset, linked by an 8 bytes
+			// next pointer
+			ifd1 := uint64(len(data))
+			bo.PutUint64(data[16+8+2*20:], ifd1)
+			data = bo.AppendUint64(data, 1)
+			data = append(data, data[16+8:16+8+20]...)
+			data = bo.AppendUint64(data, 0)
+
+			m, err := ParseTIFF(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Exif == nil {
+				t.Fatalf("Exif IFD not read, warnings %v", m.Warnings)
+			}
+			if e, _ := m.Get(tag.ExposureTime); !reflect.DeepEqual(e.Value, []tag.Rational{{Num: 1, Den: 250}}) {
+				t.Errorf("ExposureTime %#v", e.Value)
+			}
+			if m.IFD1 == nil || m.IFD1.Offset != ifd1 {
+				t.Fatalf("IFD1 %+v, want one at %d", m.IFD1, ifd1)
+			}
+			if e, _ := m.IFD1.Get(uint16(tag.Make)); e.Value != "ACME" {
+				t.Errorf("IFD1 Make %#v", e.Value)
+			}
+		})
+	}
+}
+
+func TestReadBigTIFFHeader(t *testing.T) {
+	valid := bigTIFF(LittleEndian, []bigEntry{{uint16(tag.Make), uint16(tag.ASCII), 5, []byte("ACME\x00")}}, nil)
+	tests := []struct {
+		name  string
+		patch func([]byte) []byte
+		want  error
+	}{
+		{"offsets of 4 bytes", func(d []byte) []byte { d[4] = 4; return d }, ErrTIFFMagic},
+		{"non-zero reserved", func(d []byte) []byte { d[6] = 1; return d }, ErrTIFFMagic},
+		{"magic 44", func(d []byte) []byte { d[2] = 44; return d }, ErrTIFFMagic},
+		{"truncated header", func(d []byte) []byte { return d[:12] }, ErrTruncated},
+		{"IFD0 out of range", func(d []byte) []byte { d[15] = 1; return d }, ErrTruncated},
+	}
+	for _, tt := range tests {
+		data := tt.patch(append([]byte(nil), valid...))
+		if _, err := ParseTIFF(data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
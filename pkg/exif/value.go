@@ -0,0 +1,102 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"exif/pkg/tag"
+)
+
+// decodeValue converts the raw bytes of an entry (count*TypeSizes[tp] bytes,
+// either in-line or out-of-line) to a Go value:
+//
+//	BYTE, UNDEFINED -> []byte
+//	ASCII           -> string (trailing NULs dropped)
+//	SHORT           -> []uint16
+//	LONG, IFD4      -> []uint32
+//	RATIONAL        -> []tag.Rational
+//	SBYTE           -> []int8
+//	SSHORT          -> []int16
+//	SLONG           -> []int32
+//	SRATIONAL       -> []tag.SRational
+//	FLOAT           -> []float32
+//	DOUBLE          -> []float64
+//	LONG8, IFD8     -> []uint64
+//	SLONG8          -> []int64
+func decodeValue(bo binary.ByteOrder, tp tag.Type, cnt uint64, raw []byte) any {
+	switch tp {
+	case tag.BYTE, tag.UNDEFINED:
+		return raw
+	case tag.ASCII:
+		return string(bytes.TrimRight(raw, "\x00"))
+	case tag.SHORT:
+		arr := make([]uint16, cnt)
+		for j := range arr {
+			arr[j] = bo.Uint16(raw[j*2:])
+		}
+		return arr
+	case tag.LONG, tag.IFD4:
+		arr := make([]uint32, cnt)
+		for j := range arr {
+			arr[j] = bo.Uint32(raw[j*4:])
+		}
+		return arr
+	case tag.RATIONAL:
+		rats := make([]tag.Rational, cnt)
+		for j := range rats {
+			rats[j] = tag.Rational{Num: bo.Uint32(raw[j*8:]), Den: bo.Uint32(raw[j*8+4:])}
+		}
+		return rats
+	case tag.SBYTE:
+		arr := make([]int8, cnt)
+		for j := range arr {
+			arr[j] = int8(raw[j])
+		}
+		return arr
+	case tag.SSHORT:
+		arr := make([]int16, cnt)
+		for j := range arr {
+			arr[j] = int16(bo.Uint16(raw[j*2:]))
+		}
+		return arr
+	case tag.SLONG:
+		arr := make([]int32, cnt)
+		for j := range arr {
+			arr[j] = int32(bo.Uint32(raw[j*4:]))
+		}
+		return arr
+	case tag.SRATIONAL:
+		rats := make([]tag.SRational, cnt)
+		for j := range rats {
+			rats[j] = tag.SRational{Num: int32(bo.Uint32(raw[j*8:])), Den: int32(bo.Uint32(raw[j*8+4:]))}
+		}
+		return rats
+	case tag.FLOAT:
+		arr := make([]float32, cnt)
+		for j := range arr {
+			arr[j] = math.Float32frombits(bo.Uint32(raw[j*4:]))
+		}
+		return arr
+	case tag.DOUBLE:
+		arr := make([]float64, cnt)
+		for j := range arr {
+			arr[j] = math.Float64frombits(bo.Uint64(raw[j*8:]))
+		}
+		return arr
+	case tag.LONG8, tag.IFD8:
+		arr := make([]uint64, cnt)
+		for j := range arr {
+			arr[j] = bo.Uint64(raw[j*8:])
+		}
+		return arr
+	case tag.SLONG8:
+		arr := make([]int64, cnt)
+		for j := range arr {
+			arr[j] = int64(bo.Uint64(raw[j*8:]))
+		}
+		return arr
+	}
+
+	return raw
+}
@@ -0,0 +1,94 @@
+package exif
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// WriteJPEG copies the JPEG stream of src to dst with the Exif APP1 segment
+// encoded from meta: the first Exif APP1 of src is replaced, later ones are
+// dropped, and when src has none it's inserted after SOI and the APP0
+// (JFIF, JFXX) segments. A nil meta, or one without IFD0, removes the Exif
+// APP1 segments.
+//
+// The other segments (DQT, DHT, SOFn, ...) and the data following SOS
+// (entropy-coded data, later scans, EOI) are copied byte for byte,
+// so the image isn't re-encoded.
+//
+// Only the Exif metadata is written: XMP, which may hold its own copy of
+// the dates or of the GPS position, IPTC and COM segments are copied
+// unchanged, use Strip to remove them. The MakerNote is copied as an opaque
+// value, kept at its offset when unchanged, see EncodeTIFF.
+func WriteJPEG(dst io.Writer, src io.Reader, meta *Metadata) error {
+	var app1 *APPn
+	if meta != nil && meta.IFD0 != nil {
+		payload, err := meta.EncodeAPP1()
+		if err != nil {
+			return errors.Wrap(err, "failed to encode metadata")
+		}
+		app1 = &APPn{N: MarkerAPP1, Data: payload}
+	}
+
+	return rewriteJPEG(dst, src, func(segs Segments) (Segments, error) {
+		return segs.WithExif(app1), nil
+	})
+}
+
+// rewriteJPEG copies the JPEG stream of src to dst, the segments up to SOS
+// being the ones returned by edit; the rest of the stream is copied as is.
+func rewriteJPEG(dst io.Writer, src io.Reader, edit func(Segments) (Segments, error)) error {
+	var segs Segments
+	sr := NewSegmentReader(src)
+	for {
+		seg, err := sr.NextSegment()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read segment")
+		}
+		segs = append(segs, seg)
+	}
+
+	segs, err := edit(segs)
+	if err != nil {
+		return err
+	}
+	for _, seg := range segs {
+		if _, err := dst.Write(SegmentBytes(seg)); err != nil {
+			return errors.Wrap(err, "failed to write segment")
+		}
+	}
+
+	// entropy-coded data up to EOI
+	if _, err := io.Copy(dst, sr.Rest()); err != nil {
+		return errors.Wrap(err, "failed to copy scan data")
+	}
+	return nil
+}
+
+// WithExif returns the segments with app1 in place of the first Exif APP1
+// segment and without the other ones. When there is none app1 is inserted
+// after SOI and the APP0 segments; a nil app1 removes the Exif APP1 segments.
+func (ss Segments) WithExif(app1 *APPn) Segments {
+	out := make(Segments, 0, len(ss)+1)
+	written := app1 == nil
+	for _, s := range ss {
+		if a, ok := s.(*APPn); ok && a.N == MarkerAPP1 && a.Is(SignatureExif) {
+			if !written {
+				out = append(out, app1)
+				written = true
+			}
+			continue
+		}
+
+		// the new segment goes before the first one that's neither SOI nor APP0
+		if !written && s.Marker() != MarkerSOI && s.Marker() != MarkerAPP0 {
+			out = append(out, app1)
+			written = true
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+package exif
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"exif/pkg/tag"
+)
+
+// split returns the segments of a JPEG stream up to SOS, Exif APP1
+// segments excluded, and the data following SOS.
+func split(t *testing.T, data []byte) (segs [][]byte, exif *APPn, rest []byte) {
+	t.Helper()
+	sr := NewSegmentReader(bytes.NewReader(data))
+	for {
+		seg, err := sr.NextSegment()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a, ok := seg.(*APPn); ok && a.N == MarkerAPP1 && a.Is(SignatureExif) {
+			if exif == nil {
+				exif = a
+			}
+			continue
+		}
+		segs = append(segs, SegmentBytes(seg))
+	}
+	rest, err := io.ReadAll(sr.Rest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return segs, exif, rest
+}
+
+func TestWriteJPEG(t *testing.T) {
+	for _, path := range []string{"../../DSCN0012.jpg", "../../fujifilm-dx10.jpg"} {
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			thumb, err := m.Thumbnail()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Set(tag.Software, "exif test"); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteJPEG(&buf, bytes.NewReader(data), m); err != nil {
+				t.Fatal(err)
+			}
+
+			wantSegs, _, wantRest := split(t, data)
+			gotSegs, app1, gotRest := split(t, buf.Bytes())
+			if app1 == nil {
+				t.Fatal("no Exif APP1 written")
+			}
+			if len(gotSegs) != len(wantSegs) {
+				t.Fatalf("%d segments, want %d", len(gotSegs), len(wantSegs))
+			}
+			for i := range wantSegs {
+				if !bytes.Equal(gotSegs[i], wantSegs[i]) {
+					t.Errorf("segment %d differs", i)
+				}
+			}
+			if !bytes.Equal(gotRest, wantRest) {
+				t.Errorf("scan data differs: %d bytes, want %d", len(gotRest), len(wantRest))
+			}
+
+			got, err := Decode(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e, _ := got.Get(tag.Software); e.Value != "exif test" {
+				t.Errorf("Software %#v", e.Value)
+			}
+			if note, ok := m.Get(tag.MakerNote); ok {
+				e, _ := got.Get(tag.MakerNote)
+				want, _ := m.pinnedOffset(note, note.Value.([]byte))
+				if off, _ := got.pinnedOffset(e, note.Value.([]byte)); off != want {
+					t.Errorf("MakerNote at %d, want %d", off, want)
+				}
+			}
+			gotThumb, err := got.Thumbnail()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotThumb.Compression != thumb.Compression || !bytes.Equal(gotThumb.Data, thumb.Data) {
+				t.Errorf("thumbnail differs: %d bytes, want %d", len(gotThumb.Data), len(thumb.Data))
+			}
+		})
+	}
+}
+
+func TestWriteJPEGInsert(t *testing.T) {
+	data, err := os.ReadFile("../../image.jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Metadata{}
+	if err := m.Set(tag.Make, "ACME"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJPEG(&buf, bytes.NewReader(data), m); err != nil {
+		t.Fatal(err)
+	}
+	segs, err := ReadSegments(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// after SOI and the JFIF APP0
+	if len(segs) < 3 || segs[1].Marker() != MarkerAPP0 || segs[2] != Segment(segs.Exif()) {
+		t.Fatalf("Exif APP1 not inserted after APP0: %v", segs)
+	}
+	got, err := Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := got.Get(tag.Make); e.Value != "ACME" {
+		t.Errorf("Make %#v", e.Value)
+	}
+	if got.JFIF == nil {
+		t.Error("JFIF segment lost")
+	}
+
+	// a nil Metadata removes the segment
+	var stripped bytes.Buffer
+	if err := WriteJPEG(&stripped, bytes.NewReader(buf.Bytes()), nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stripped.Bytes(), data) {
+		t.Error("removing the Exif APP1 doesn't give back the original")
+	}
+}
+
+func TestWriteJPEGOtherMetadata(t *testing.T) {
+	data := privateJPEG(t)
+	m, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Delete(tag.GPSInfo)
+
+	var buf bytes.Buffer
+	if err := WriteJPEG(&buf, bytes.NewReader(data), m); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ReadSegments(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSegments(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// XMP and COM segments are left as they are
+	if xmp := got.APP(MarkerAPP1, SignatureXMP); xmp == nil || !bytes.Equal(xmp.Data, want.APP(MarkerAPP1, SignatureXMP).Data) {
+		t.Error("XMP segment changed")
+	}
+	if c := got.Find(MarkerCOM); len(c) != 1 || !bytes.Equal(SegmentBytes(c[0]), SegmentBytes(want.Find(MarkerCOM)[0])) {
+		t.Errorf("COM segments %v", c)
+	}
+}
@@ -0,0 +1,607 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The types below are the values of the enumerated tags. They are named after
+// the tag with a Value suffix, the tag name being its constant: a decoded
+// Orientation of 6 is OrientationValue(6), OrientationRotate90.
+// String returns the name of a value, or "Unknown (n)" when it isn't defined
+// by the specification, and IsValid reports whether it is.
+
+// OrientationValue is the value of Orientation, the position of row 0 and
+// column 0 of the stored image relative to the visual one.
+type OrientationValue uint16
+
+const (
+	OrientationNormal                    OrientationValue = 1
+	OrientationMirrorHorizontal          OrientationValue = 2
+	OrientationRotate180                 OrientationValue = 3
+	OrientationMirrorVertical            OrientationValue = 4
+	OrientationMirrorHorizontalRotate270 OrientationValue = 5
+	OrientationRotate90                  OrientationValue = 6
+	OrientationMirrorHorizontalRotate90  OrientationValue = 7
+	OrientationRotate270                 OrientationValue = 8
+)
+
+var orientationNames = map[OrientationValue]string{
+	OrientationNormal:                    "Horizontal (normal)",
+	OrientationMirrorHorizontal:          "Mirror horizontal",
+	OrientationRotate180:                 "Rotate 180",
+	OrientationMirrorVertical:            "Mirror vertical",
+	OrientationMirrorHorizontalRotate270: "Mirror horizontal and rotate 270 CW",
+	OrientationRotate90:                  "Rotate 90 CW",
+	OrientationMirrorHorizontalRotate90:  "Mirror horizontal and rotate 90 CW",
+	OrientationRotate270:                 "Rotate 270 CW",
+}
+
+func (v OrientationValue) String() string { return nameOf(v, orientationNames) }
+func (v OrientationValue) IsValid() bool  { return isDefined(v, orientationNames) }
+
+// ResolutionUnitValue is the value of ResolutionUnit and FocalPlaneResolutionUnit.
+type ResolutionUnitValue uint16
+
+const (
+	ResolutionUnitNone       ResolutionUnitValue = 1
+	ResolutionUnitInch       ResolutionUnitValue = 2
+	ResolutionUnitCentimeter ResolutionUnitValue = 3
+)
+
+var resolutionUnitNames = map[ResolutionUnitValue]string{
+	ResolutionUnitNone:       "None",
+	ResolutionUnitInch:       "inches",
+	ResolutionUnitCentimeter: "cm",
+}
+
+func (v ResolutionUnitValue) String() string { return nameOf(v, resolutionUnitNames) }
+func (v ResolutionUnitValue) IsValid() bool  { return isDefined(v, resolutionUnitNames) }
+
+// YCbCrPositioningValue is the value of YCbCrPositioning, the position of the
+// chrominance samples relative to the luminance ones.
+type YCbCrPositioningValue uint16
+
+const (
+	YCbCrPositioningCentered YCbCrPositioningValue = 1
+	YCbCrPositioningCosited  YCbCrPositioningValue = 2
+)
+
+var yCbCrPositioningNames = map[YCbCrPositioningValue]string{
+	YCbCrPositioningCentered: "Centered",
+	YCbCrPositioningCosited:  "Co-sited",
+}
+
+func (v YCbCrPositioningValue) String() string { return nameOf(v, yCbCrPositioningNames) }
+func (v YCbCrPositioningValue) IsValid() bool  { return isDefined(v, yCbCrPositioningNames) }
+
+// CompressionValue is the value of Compression.
+type CompressionValue uint16
+
+const (
+	CompressionNone      CompressionValue = 1
+	CompressionCCITT1D   CompressionValue = 2
+	CompressionGroup3Fax CompressionValue = 3
+	CompressionGroup4Fax CompressionValue = 4
+	CompressionLZW       CompressionValue = 5
+	CompressionOldJPEG   CompressionValue = 6
+	CompressionJPEG      CompressionValue = 7
+	CompressionDeflate   CompressionValue = 8
+	CompressionPackBits  CompressionValue = 32773
+	CompressionLossyJPEG CompressionValue = 34892
+)
+
+var compressionNames = map[CompressionValue]string{
+	CompressionNone:      "Uncompressed",
+	CompressionCCITT1D:   "CCITT 1D",
+	CompressionGroup3Fax: "T4/Group 3 Fax",
+	CompressionGroup4Fax: "T6/Group 4 Fax",
+	CompressionLZW:       "LZW",
+	CompressionOldJPEG:   "JPEG (old-style)",
+	CompressionJPEG:      "JPEG",
+	CompressionDeflate:   "Adobe Deflate",
+	CompressionPackBits:  "PackBits",
+	CompressionLossyJPEG: "Lossy JPEG",
+}
+
+func (v CompressionValue) String() string { return nameOf(v, compressionNames) }
+func (v CompressionValue) IsValid() bool  { return isDefined(v, compressionNames) }
+
+// PhotometricInterpretationValue is the value of PhotometricInterpretation,
+// the color space of the image data.
+type PhotometricInterpretationValue uint16
+
+const (
+	PhotometricWhiteIsZero      PhotometricInterpretationValue = 0
+	PhotometricBlackIsZero      PhotometricInterpretationValue = 1
+	PhotometricRGB              PhotometricInterpretationValue = 2
+	PhotometricPalette          PhotometricInterpretationValue = 3
+	PhotometricTransparencyMask PhotometricInterpretationValue = 4
+	PhotometricCMYK             PhotometricInterpretationValue = 5
+	PhotometricYCbCr            PhotometricInterpretationValue = 6
+	PhotometricCIELab           PhotometricInterpretationValue = 8
+	PhotometricCFA              PhotometricInterpretationValue = 32803
+	PhotometricLinearRaw        PhotometricInterpretationValue = 34892
+)
+
+var photometricNames = map[PhotometricInterpretationValue]string{
+	PhotometricWhiteIsZero:      "WhiteIsZero",
+	PhotometricBlackIsZero:      "BlackIsZero",
+	PhotometricRGB:              "RGB",
+	PhotometricPalette:          "RGB Palette",
+	PhotometricTransparencyMask: "Transparency Mask",
+	PhotometricCMYK:             "CMYK",
+	PhotometricYCbCr:            "YCbCr",
+	PhotometricCIELab:           "CIELab",
+	PhotometricCFA:              "Color Filter Array",
+	PhotometricLinearRaw:        "Linear Raw",
+}
+
+func (v PhotometricInterpretationValue) String() string { return nameOf(v, photometricNames) }
+func (v PhotometricInterpretationValue) IsValid() bool  { return isDefined(v, photometricNames) }
+
+// PlanarConfigurationValue is the value of PlanarConfiguration.
+type PlanarConfigurationValue uint16
+
+const (
+	PlanarConfigurationChunky PlanarConfigurationValue = 1
+	PlanarConfigurationPlanar PlanarConfigurationValue = 2
+)
+
+var planarConfigurationNames = map[PlanarConfigurationValue]string{
+	PlanarConfigurationChunky: "Chunky",
+	PlanarConfigurationPlanar: "Planar",
+}
+
+func (v PlanarConfigurationValue) String() string { return nameOf(v, planarConfigurationNames) }
+func (v PlanarConfigurationValue) IsValid() bool  { return isDefined(v, planarConfigurationNames) }
+
+// ExposureProgramValue is the value of ExposureProgram.
+type ExposureProgramValue uint16
+
+const (
+	ExposureProgramNotDefined       ExposureProgramValue = 0
+	ExposureProgramManual           ExposureProgramValue = 1
+	ExposureProgramNormal           ExposureProgramValue = 2
+	ExposureProgramAperturePriority ExposureProgramValue = 3
+	ExposureProgramShutterPriority  ExposureProgramValue = 4
+	ExposureProgramCreative         ExposureProgramValue = 5
+	ExposureProgramAction           ExposureProgramValue = 6
+	ExposureProgramPortrait         ExposureProgramValue = 7
+	ExposureProgramLandscape        ExposureProgramValue = 8
+	ExposureProgramBulb             ExposureProgramValue = 9
+)
+
+var exposureProgramNames = map[ExposureProgramValue]string{
+	ExposureProgramNotDefined:       "Not Defined",
+	ExposureProgramManual:           "Manual",
+	ExposureProgramNormal:           "Program AE",
+	ExposureProgramAperturePriority: "Aperture-priority AE",
+	ExposureProgramShutterPriority:  "Shutter speed priority AE",
+	ExposureProgramCreative:         "Creative (Slow speed)",
+	ExposureProgramAction:           "Action (High speed)",
+	ExposureProgramPortrait:         "Portrait",
+	ExposureProgramLandscape:        "Landscape",
+	ExposureProgramBulb:             "Bulb",
+}
+
+func (v ExposureProgramValue) String() string { return nameOf(v, exposureProgramNames) }
+func (v ExposureProgramValue) IsValid() bool  { return isDefined(v, exposureProgramNames) }
+
+// MeteringModeValue is the value of MeteringMode.
+type MeteringModeValue uint16
+
+const (
+	MeteringModeUnknown               MeteringModeValue = 0
+	MeteringModeAverage               MeteringModeValue = 1
+	MeteringModeCenterWeightedAverage MeteringModeValue = 2
+	MeteringModeSpot                  MeteringModeValue = 3
+	MeteringModeMultiSpot             MeteringModeValue = 4
+	MeteringModeMultiSegment          MeteringModeValue = 5
+	MeteringModePartial               MeteringModeValue = 6
+	MeteringModeOther                 MeteringModeValue = 255
+)
+
+var meteringModeNames = map[MeteringModeValue]string{
+	MeteringModeUnknown:               "Unknown",
+	MeteringModeAverage:               "Average",
+	MeteringModeCenterWeightedAverage: "Center-weighted average",
+	MeteringModeSpot:                  "Spot",
+	MeteringModeMultiSpot:             "Multi-spot",
+	MeteringModeMultiSegment:          "Multi-segment",
+	MeteringModePartial:               "Partial",
+	MeteringModeOther:                 "Other",
+}
+
+func (v MeteringModeValue) String() string { return nameOf(v, meteringModeNames) }
+func (v MeteringModeValue) IsValid() bool  { return isDefined(v, meteringModeNames) }
+
+// LightSourceValue is the value of LightSource.
+type LightSourceValue uint16
+
+const (
+	LightSourceUnknown              LightSourceValue = 0
+	LightSourceDaylight             LightSourceValue = 1
+	LightSourceFluorescent          LightSourceValue = 2
+	LightSourceTungsten             LightSourceValue = 3
+	LightSourceFlash                LightSourceValue = 4
+	LightSourceFineWeather          LightSourceValue = 9
+	LightSourceCloudy               LightSourceValue = 10
+	LightSourceShade                LightSourceValue = 11
+	LightSourceDaylightFluorescent  LightSourceValue = 12
+	LightSourceDayWhiteFluorescent  LightSourceValue = 13
+	LightSourceCoolWhiteFluorescent LightSourceValue = 14
+	LightSourceWhiteFluorescent     LightSourceValue = 15
+	LightSourceWarmWhiteFluorescent LightSourceValue = 16
+	LightSourceStandardLightA       LightSourceValue = 17
+	LightSourceStandardLightB       LightSourceValue = 18
+	LightSourceStandardLightC       LightSourceValue = 19
+	LightSourceD55                  LightSourceValue = 20
+	LightSourceD65                  LightSourceValue = 21
+	LightSourceD75                  LightSourceValue = 22
+	LightSourceD50                  LightSourceValue = 23
+	LightSourceISOStudioTungsten    LightSourceValue = 24
+	LightSourceOther                LightSourceValue = 255
+)
+
+var lightSourceNames = map[LightSourceValue]string{
+	LightSourceUnknown:              "Unknown",
+	LightSourceDaylight:             "Daylight",
+	LightSourceFluorescent:          "Fluorescent",
+	LightSourceTungsten:             "Tungsten (Incandescent)",
+	LightSourceFlash:                "Flash",
+	LightSourceFineWeather:          "Fine Weather",
+	LightSourceCloudy:               "Cloudy",
+	LightSourceShade:                "Shade",
+	LightSourceDaylightFluorescent:  "Daylight Fluorescent",
+	LightSourceDayWhiteFluorescent:  "Day White Fluorescent",
+	LightSourceCoolWhiteFluorescent: "Cool White Fluorescent",
+	LightSourceWhiteFluorescent:     "White Fluorescent",
+	LightSourceWarmWhiteFluorescent: "Warm White Fluorescent",
+	LightSourceStandardLightA:       "Standard Light A",
+	LightSourceStandardLightB:       "Standard Light B",
+	LightSourceStandardLightC:       "Standard Light C",
+	LightSourceD55:                  "D55",
+	LightSourceD65:                  "D65",
+	LightSourceD75:                  "D75",
+	LightSourceD50:                  "D50",
+	LightSourceISOStudioTungsten:    "ISO Studio Tungsten",
+	LightSourceOther:                "Other",
+}
+
+func (v LightSourceValue) String() string { return nameOf(v, lightSourceNames) }
+func (v LightSourceValue) IsValid() bool  { return isDefined(v, lightSourceNames) }
+
+// FlashValue is the value of Flash, a bitfield: whether the flash fired
+// (FlashFired), its return light (FlashReturnMask), its mode (FlashModeMask),
+// whether the camera has no flash function (FlashNoFunction) and whether
+// red-eye reduction was used (FlashRedEyeReduction).
+type FlashValue uint16
+
+const (
+	FlashFired FlashValue = 0x01
+
+	// return light detection, bits 1 and 2
+	FlashReturnMask        FlashValue = 0x06
+	FlashReturnNotDetected FlashValue = 0x04
+	FlashReturnDetected    FlashValue = 0x06
+
+	// flash mode, bits 3 and 4
+	FlashModeMask FlashValue = 0x18
+	FlashModeOn   FlashValue = 0x08
+	FlashModeOff  FlashValue = 0x10
+	FlashModeAuto FlashValue = 0x18
+
+	FlashNoFunction      FlashValue = 0x20
+	FlashRedEyeReduction FlashValue = 0x40
+)
+
+// Fired reports whether the flash fired.
+func (v FlashValue) Fired() bool { return v&FlashFired != 0 }
+
+// Return returns the return light bits: 0 when there is no detection
+// function, FlashReturnNotDetected or FlashReturnDetected.
+func (v FlashValue) Return() FlashValue { return v & FlashReturnMask }
+
+// Mode returns the flash mode bits: 0 when unknown, FlashModeOn
+// (compulsory firing), FlashModeOff (compulsory suppression) or FlashModeAuto.
+func (v FlashValue) Mode() FlashValue { return v & FlashModeMask }
+
+// NoFunction reports whether the camera has no flash function.
+func (v FlashValue) NoFunction() bool { return v&FlashNoFunction != 0 }
+
+// RedEyeReduction reports whether red-eye reduction was used.
+func (v FlashValue) RedEyeReduction() bool { return v&FlashRedEyeReduction != 0 }
+
+// IsValid reports whether v has no undefined bit and no reserved return value.
+func (v FlashValue) IsValid() bool {
+	return v&^0x7F == 0 && v.Return() != 0x02
+}
+
+// String describes v, e.g. "Flash fired, auto mode, return detected".
+func (v FlashValue) String() string {
+	if v.NoFunction() {
+		return "No flash function"
+	}
+
+	parts := []string{"Flash did not fire"}
+	if v.Fired() {
+		parts[0] = "Flash fired"
+	}
+	switch v.Mode() {
+	case FlashModeOn:
+		parts = append(parts, "compulsory flash mode")
+	case FlashModeOff:
+		parts = append(parts, "compulsory flash suppression")
+	case FlashModeAuto:
+		parts = append(parts, "auto mode")
+	}
+	if v.RedEyeReduction() {
+		parts = append(parts, "red-eye reduction")
+	}
+	switch v.Return() {
+	case FlashReturnNotDetected:
+		parts = append(parts, "return not detected")
+	case FlashReturnDetected:
+		parts = append(parts, "return detected")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SensingMethodValue is the value of SensingMethod, the type of image sensor.
+type SensingMethodValue uint16
+
+const (
+	SensingMethodNotDefined            SensingMethodValue = 1
+	SensingMethodOneChipColorArea      SensingMethodValue = 2
+	SensingMethodTwoChipColorArea      SensingMethodValue = 3
+	SensingMethodThreeChipColorArea    SensingMethodValue = 4
+	SensingMethodColorSequentialArea   SensingMethodValue = 5
+	SensingMethodTrilinear             SensingMethodValue = 7
+	SensingMethodColorSequentialLinear SensingMethodValue = 8
+)
+
+var sensingMethodNames = map[SensingMethodValue]string{
+	SensingMethodNotDefined:            "Not defined",
+	SensingMethodOneChipColorArea:      "One-chip color area",
+	SensingMethodTwoChipColorArea:      "Two-chip color area",
+	SensingMethodThreeChipColorArea:    "Three-chip color area",
+	SensingMethodColorSequentialArea:   "Color sequential area",
+	SensingMethodTrilinear:             "Trilinear",
+	SensingMethodColorSequentialLinear: "Color sequential linear",
+}
+
+func (v SensingMethodValue) String() string { return nameOf(v, sensingMethodNames) }
+func (v SensingMethodValue) IsValid() bool  { return isDefined(v, sensingMethodNames) }
+
+// ColorSpaceValue is the value of ColorSpace.
+type ColorSpaceValue uint16
+
+const (
+	ColorSpaceSRGB         ColorSpaceValue = 1
+	ColorSpaceAdobeRGB     ColorSpaceValue = 2
+	ColorSpaceWideGamutRGB ColorSpaceValue = 0xFFFD
+	ColorSpaceICCProfile   ColorSpaceValue = 0xFFFE
+	ColorSpaceUncalibrated ColorSpaceValue = 0xFFFF
+)
+
+var colorSpaceNames = map[ColorSpaceValue]string{
+	ColorSpaceSRGB:         "sRGB",
+	ColorSpaceAdobeRGB:     "Adobe RGB",
+	ColorSpaceWideGamutRGB: "Wide Gamut RGB",
+	ColorSpaceICCProfile:   "ICC Profile",
+	ColorSpaceUncalibrated: "Uncalibrated",
+}
+
+func (v ColorSpaceValue) String() string { return nameOf(v, colorSpaceNames) }
+func (v ColorSpaceValue) IsValid() bool  { return isDefined(v, colorSpaceNames) }
+
+// CustomRenderedValue is the value of CustomRendered.
+type CustomRenderedValue uint16
+
+const (
+	CustomRenderedNormal CustomRenderedValue = 0
+	CustomRenderedCustom CustomRenderedValue = 1
+)
+
+var customRenderedNames = map[CustomRenderedValue]string{
+	CustomRenderedNormal: "Normal",
+	CustomRenderedCustom: "Custom",
+}
+
+func (v CustomRenderedValue) String() string { return nameOf(v, customRenderedNames) }
+func (v CustomRenderedValue) IsValid() bool  { return isDefined(v, customRenderedNames) }
+
+// ExposureModeValue is the value of ExposureMode.
+type ExposureModeValue uint16
+
+const (
+	ExposureModeAuto        ExposureModeValue = 0
+	ExposureModeManual      ExposureModeValue = 1
+	ExposureModeAutoBracket ExposureModeValue = 2
+)
+
+var exposureModeNames = map[ExposureModeValue]string{
+	ExposureModeAuto:        "Auto",
+	ExposureModeManual:      "Manual",
+	ExposureModeAutoBracket: "Auto bracket",
+}
+
+func (v ExposureModeValue) String() string { return nameOf(v, exposureModeNames) }
+func (v ExposureModeValue) IsValid() bool  { return isDefined(v, exposureModeNames) }
+
+// WhiteBalanceValue is the value of WhiteBalance.
+type WhiteBalanceValue uint16
+
+const (
+	WhiteBalanceAuto   WhiteBalanceValue = 0
+	WhiteBalanceManual WhiteBalanceValue = 1
+)
+
+var whiteBalanceNames = map[WhiteBalanceValue]string{
+	WhiteBalanceAuto:   "Auto",
+	WhiteBalanceManual: "Manual",
+}
+
+func (v WhiteBalanceValue) String() string { return nameOf(v, whiteBalanceNames) }
+func (v WhiteBalanceValue) IsValid() bool  { return isDefined(v, whiteBalanceNames) }
+
+// SceneCaptureTypeValue is the value of SceneCaptureType.
+type SceneCaptureTypeValue uint16
+
+const (
+	SceneCaptureTypeStandard  SceneCaptureTypeValue = 0
+	SceneCaptureTypeLandscape SceneCaptureTypeValue = 1
+	SceneCaptureTypePortrait  SceneCaptureTypeValue = 2
+	SceneCaptureTypeNight     SceneCaptureTypeValue = 3
+)
+
+var sceneCaptureTypeNames = map[SceneCaptureTypeValue]string{
+	SceneCaptureTypeStandard:  "Standard",
+	SceneCaptureTypeLandscape: "Landscape",
+	SceneCaptureTypePortrait:  "Portrait",
+	SceneCaptureTypeNight:     "Night",
+}
+
+func (v SceneCaptureTypeValue) String() string { return nameOf(v, sceneCaptureTypeNames) }
+func (v SceneCaptureTypeValue) IsValid() bool  { return isDefined(v, sceneCaptureTypeNames) }
+
+// GainControlValue is the value of GainControl.
+type GainControlValue uint16
+
+const (
+	GainControlNone         GainControlValue = 0
+	GainControlLowGainUp    GainControlValue = 1
+	GainControlHighGainUp   GainControlValue = 2
+	GainControlLowGainDown  GainControlValue = 3
+	GainControlHighGainDown GainControlValue = 4
+)
+
+var gainControlNames = map[GainControlValue]string{
+	GainControlNone:         "None",
+	GainControlLowGainUp:    "Low gain up",
+	GainControlHighGainUp:   "High gain up",
+	GainControlLowGainDown:  "Low gain down",
+	GainControlHighGainDown: "High gain down",
+}
+
+func (v GainControlValue) String() string { return nameOf(v, gainControlNames) }
+func (v GainControlValue) IsValid() bool  { return isDefined(v, gainControlNames) }
+
+// ContrastValue is the value of Contrast.
+type ContrastValue uint16
+
+const (
+	ContrastNormal ContrastValue = 0
+	ContrastLow    ContrastValue = 1
+	ContrastHigh   ContrastValue = 2
+)
+
+var contrastNames = map[ContrastValue]string{
+	ContrastNormal: "Normal",
+	ContrastLow:    "Low",
+	ContrastHigh:   "High",
+}
+
+func (v ContrastValue) String() string { return nameOf(v, contrastNames) }
+func (v ContrastValue) IsValid() bool  { return isDefined(v, contrastNames) }
+
+// SaturationValue is the value of Saturation.
+type SaturationValue uint16
+
+const (
+	SaturationNormal SaturationValue = 0
+	SaturationLow    SaturationValue = 1
+	SaturationHigh   SaturationValue = 2
+)
+
+var saturationNames = map[SaturationValue]string{
+	SaturationNormal: "Normal",
+	SaturationLow:    "Low",
+	SaturationHigh:   "High",
+}
+
+func (v SaturationValue) String() string { return nameOf(v, saturationNames) }
+func (v SaturationValue) IsValid() bool  { return isDefined(v, saturationNames) }
+
+// SharpnessValue is the value of Sharpness.
+type SharpnessValue uint16
+
+const (
+	SharpnessNormal SharpnessValue = 0
+	SharpnessSoft   SharpnessValue = 1
+	SharpnessHard   SharpnessValue = 2
+)
+
+var sharpnessNames = map[SharpnessValue]string{
+	SharpnessNormal: "Normal",
+	SharpnessSoft:   "Soft",
+	SharpnessHard:   "Hard",
+}
+
+func (v SharpnessValue) String() string { return nameOf(v, sharpnessNames) }
+func (v SharpnessValue) IsValid() bool  { return isDefined(v, sharpnessNames) }
+
+// SubjectDistanceRangeValue is the value of SubjectDistanceRange.
+type SubjectDistanceRangeValue uint16
+
+const (
+	SubjectDistanceRangeUnknown SubjectDistanceRangeValue = 0
+	SubjectDistanceRangeMacro   SubjectDistanceRangeValue = 1
+	SubjectDistanceRangeClose   SubjectDistanceRangeValue = 2
+	SubjectDistanceRangeDistant SubjectDistanceRangeValue = 3
+)
+
+var subjectDistanceRangeNames = map[SubjectDistanceRangeValue]string{
+	SubjectDistanceRangeUnknown: "Unknown",
+	SubjectDistanceRangeMacro:   "Macro",
+	SubjectDistanceRangeClose:   "Close",
+	SubjectDistanceRangeDistant: "Distant",
+}
+
+func (v SubjectDistanceRangeValue) String() string { return nameOf(v, subjectDistanceRangeNames) }
+func (v SubjectDistanceRangeValue) IsValid() bool  { return isDefined(v, subjectDistanceRangeNames) }
+
+// FileSourceValue is the value of FileSource, an UNDEFINED byte.
+type FileSourceValue uint16
+
+const (
+	FileSourceFilmScanner            FileSourceValue = 1
+	FileSourceReflectionPrintScanner FileSourceValue = 2
+	FileSourceDigitalCamera          FileSourceValue = 3
+)
+
+var fileSourceNames = map[FileSourceValue]string{
+	FileSourceFilmScanner:            "Film Scanner",
+	FileSourceReflectionPrintScanner: "Reflection Print Scanner",
+	FileSourceDigitalCamera:          "Digital Camera",
+}
+
+func (v FileSourceValue) String() string { return nameOf(v, fileSourceNames) }
+func (v FileSourceValue) IsValid() bool  { return isDefined(v, fileSourceNames) }
+
+// SceneTypeValue is the value of SceneType, an UNDEFINED byte.
+type SceneTypeValue uint16
+
+const (
+	SceneTypeDirectlyPhotographed SceneTypeValue = 1
+)
+
+var sceneTypeNames = map[SceneTypeValue]string{
+	SceneTypeDirectlyPhotographed: "Directly photographed",
+}
+
+func (v SceneTypeValue) String() string { return nameOf(v, sceneTypeNames) }
+func (v SceneTypeValue) IsValid() bool  { return isDefined(v, sceneTypeNames) }
+
+func nameOf[T ~uint16](v T, names map[T]string) string {
+	if s, ok := names[v]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown (%d)", v)
+}
+
+func isDefined[T ~uint16](v T, names map[T]string) bool {
+	_, ok := names[v]
+	return ok
+}
@@ -0,0 +1,114 @@
+package tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+type enumValueType interface {
+	fmt.Stringer
+	IsValid() bool
+}
+
+func TestEnumString(t *testing.T) {
+	tests := []struct {
+		v     enumValueType
+		want  string
+		valid bool
+	}{
+		{OrientationNormal, "Horizontal (normal)", true},
+		{OrientationRotate90, "Rotate 90 CW", true},
+		{OrientationValue(0), "Unknown (0)", false},
+		{OrientationValue(9), "Unknown (9)", false},
+		{ResolutionUnitInch, "inches", true},
+		{CompressionOldJPEG, "JPEG (old-style)", true},
+		{CompressionPackBits, "PackBits", true},
+		{CompressionValue(9), "Unknown (9)", false},
+		{MeteringModeUnknown, "Unknown", true},
+		{MeteringModeOther, "Other", true},
+		{MeteringModeValue(7), "Unknown (7)", false},
+		{LightSourceFineWeather, "Fine Weather", true},
+		{LightSourceValue(5), "Unknown (5)", false},
+		{ColorSpaceSRGB, "sRGB", true},
+		{ColorSpaceUncalibrated, "Uncalibrated", true},
+		{ColorSpaceValue(0), "Unknown (0)", false},
+
+		{FlashValue(0x00), "Flash did not fire", true},
+		{FlashValue(0x01), "Flash fired", true},
+		{FlashValue(0x19), "Flash fired, auto mode", true},
+		{FlashValue(0x1F), "Flash fired, auto mode, return detected", true},
+		{FlashValue(0x4D), "Flash fired, compulsory flash mode, red-eye reduction, return not detected", true},
+		{FlashValue(0x10), "Flash did not fire, compulsory flash suppression", true},
+		{FlashValue(0x20), "No flash function", true},
+		// reserved return value and undefined bit
+		{FlashValue(0x03), "Flash fired", false},
+		{FlashValue(0x81), "Flash fired", false},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("%T(%d).String() = %q, want %q", tt.v, tt.v, got, tt.want)
+		}
+		if got := tt.v.IsValid(); got != tt.valid {
+			t.Errorf("%T(%d).IsValid() = %t, want %t", tt.v, tt.v, got, tt.valid)
+		}
+	}
+}
+
+// checkNames checks every value of names is valid and named, and the
+// values around them that aren't defined are invalid.
+func checkNames[T interface {
+	~uint16
+	enumValueType
+}](t *testing.T, names map[T]string) {
+	t.Helper()
+	for v, name := range names {
+		if v.String() != name || !v.IsValid() {
+			t.Errorf("%T(%d): %q, %t, want %q, true", v, v, v.String(), v.IsValid(), name)
+		}
+		for _, u := range []T{v - 1, v + 1} {
+			if _, ok := names[u]; ok {
+				continue
+			}
+			if want := fmt.Sprintf("Unknown (%d)", u); u.String() != want || u.IsValid() {
+				t.Errorf("%T(%d): %q, %t, want %q, false", u, u, u.String(), u.IsValid(), want)
+			}
+		}
+	}
+}
+
+func TestEnumNames(t *testing.T) {
+	checkNames(t, orientationNames)
+	checkNames(t, resolutionUnitNames)
+	checkNames(t, yCbCrPositioningNames)
+	checkNames(t, compressionNames)
+	checkNames(t, photometricNames)
+	checkNames(t, planarConfigurationNames)
+	checkNames(t, exposureProgramNames)
+	checkNames(t, meteringModeNames)
+	checkNames(t, lightSourceNames)
+	checkNames(t, sensingMethodNames)
+	checkNames(t, colorSpaceNames)
+	checkNames(t, customRenderedNames)
+	checkNames(t, exposureModeNames)
+	checkNames(t, whiteBalanceNames)
+	checkNames(t, sceneCaptureTypeNames)
+	checkNames(t, gainControlNames)
+	checkNames(t, contrastNames)
+	checkNames(t, saturationNames)
+	checkNames(t, sharpnessNames)
+	checkNames(t, subjectDistanceRangeNames)
+	checkNames(t, fileSourceNames)
+	checkNames(t, sceneTypeNames)
+}
+
+func TestFlashValue(t *testing.T) {
+	v := FlashValue(0x5F)
+	if !v.Fired() || v.Mode() != FlashModeAuto || v.Return() != FlashReturnDetected || !v.RedEyeReduction() || v.NoFunction() {
+		t.Errorf("0x5F: fired %t, mode 0x%02X, return 0x%02X, red-eye %t, no function %t",
+			v.Fired(), uint16(v.Mode()), uint16(v.Return()), v.RedEyeReduction(), v.NoFunction())
+	}
+	v = FlashValue(0x20)
+	if v.Fired() || v.Mode() != 0 || v.Return() != 0 || !v.NoFunction() {
+		t.Errorf("0x20: fired %t, mode 0x%02X, return 0x%02X, no function %t", v.Fired(), uint16(v.Mode()), uint16(v.Return()), v.NoFunction())
+	}
+}
@@ -0,0 +1,272 @@
+// Command gen generates the tag constants, name maps and registry
+// table of package tag from the tags.csv spec table.
+//
+// Usage (from pkg/tag, see the go:generate directive in registry.go):
+//
+//	go run ./internal/gen -in tags.csv -out tags_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// row is a line of tags.csv.
+type row struct {
+	IFD         string
+	ID          uint16
+	Name        string
+	Types       []string
+	Count       uint32
+	Description string
+}
+
+// constant is a generated tag constant, GoType is the Go type it's declared
+// with: TIFF and Exif namespaces share the Exif type since their IDs don't
+// overlap.
+type constant struct {
+	GoType string
+	Name   string
+	ID     uint16
+}
+
+var goTypes = map[string]string{
+	"TIFF":    "Exif",
+	"Exif":    "Exif",
+	"GPS":     "GPS",
+	"Interop": "Interop",
+}
+
+var validTypes = map[string]bool{
+	"BYTE": true, "ASCII": true, "SHORT": true, "LONG": true, "RATIONAL": true, "SBYTE": true,
+	"UNDEFINED": true, "SSHORT": true, "SLONG": true, "SRATIONAL": true, "FLOAT": true, "DOUBLE": true, "IFD4": true,
+	"LONG8": true, "SLONG8": true, "IFD8": true,
+}
+
+func main() {
+	in := flag.String("in", "tags.csv", "spec table to read")
+	out := flag.String("out", "tags_gen.go", "Go file to write")
+	flag.Parse()
+
+	reserved, err := declaredNames(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows, err := readRows(*in, reserved)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	consts, err := constants(rows)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]any{
+		"In":     *in,
+		"Rows":   rows,
+		"Consts": consts,
+		"GoTypes": []struct{ Name, Recv string }{
+			{"Exif", "e"}, {"GPS", "g"}, {"Interop", "i"},
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %v\n%s", err, buf.Bytes())
+	}
+
+	if err = os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// declaredNames returns the package-level identifiers declared by the
+// files of the package of out, out excluded, which a tag name must not
+// shadow.
+func declaredNames(out string) (map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(out), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if filepath.Base(path) == filepath.Base(out) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name.Name != "tag" { // external test package
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					names[d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						names[s.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							names[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return names, nil
+}
+
+func readRows(path string, reserved map[string]bool) ([]row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 6
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []row
+	for i, rec := range records[1:] { // skip the header
+		line := i + 2
+		if _, ok := goTypes[rec[0]]; !ok {
+			return nil, fmt.Errorf("%s:%d: unknown IFD %q", path, line, rec[0])
+		}
+
+		id, err := strconv.ParseUint(rec[1], 0, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad tag ID: %w", path, line, err)
+		}
+
+		if !token.IsIdentifier(rec[2]) || !token.IsExported(rec[2]) {
+			return nil, fmt.Errorf("%s:%d: %q can't be used as a constant name", path, line, rec[2])
+		}
+		if reserved[rec[2]] {
+			return nil, fmt.Errorf("%s:%d: %q is declared by package tag", path, line, rec[2])
+		}
+
+		types := strings.Split(rec[3], "|")
+		for _, t := range types {
+			if !validTypes[t] {
+				return nil, fmt.Errorf("%s:%d: unknown field type %q", path, line, t)
+			}
+		}
+
+		count, err := strconv.ParseUint(rec[4], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad count: %w", path, line, err)
+		}
+
+		rows = append(rows, row{
+			IFD:         rec[0],
+			ID:          uint16(id),
+			Name:        rec[2],
+			Types:       types,
+			Count:       uint32(count),
+			Description: rec[5],
+		})
+	}
+
+	return rows, nil
+}
+
+// constants returns the constants to declare for rows, it fails when
+// a name or an ID is used twice for different tags.
+func constants(rows []row) ([]constant, error) {
+	var (
+		consts []constant
+		byName = make(map[string]constant)
+		byID   = make(map[string]constant)
+	)
+
+	for _, r := range rows {
+		c := constant{GoType: goTypes[r.IFD], Name: r.Name, ID: r.ID}
+		idKey := fmt.Sprintf("%s/0x%04X", c.GoType, c.ID)
+
+		if prev, ok := byName[c.Name]; ok {
+			if prev != c {
+				return nil, fmt.Errorf("name %s is used by %s 0x%04X and %s 0x%04X", c.Name, prev.GoType, prev.ID, c.GoType, c.ID)
+			}
+			// same tag in TIFF and Exif namespaces (e.g. Padding)
+			continue
+		}
+		if prev, ok := byID[idKey]; ok {
+			return nil, fmt.Errorf("%s is named both %s and %s", idKey, prev.Name, c.Name)
+		}
+
+		byName[c.Name] = c
+		byID[idKey] = c
+		consts = append(consts, c)
+	}
+
+	return consts, nil
+}
+
+var tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"hex":   func(id uint16) string { return fmt.Sprintf("0x%04X", id) },
+	"ifd":   func(s string) string { return "IFD" + s },
+	"join":  strings.Join,
+	"quote": strconv.Quote,
+}).Parse(`// Code generated by "go run ./internal/gen -in {{.In}}"; DO NOT EDIT.
+
+package tag
+
+import "fmt"
+{{range $t := .GoTypes}}
+const (
+{{- range $.Consts}}{{if eq .GoType $t.Name}}
+	{{.Name}} {{.GoType}} = {{hex .ID}}
+{{- end}}{{end}}
+)
+
+var {{$t.Recv}}Names = map[{{$t.Name}}]string{
+{{- range $.Consts}}{{if eq .GoType $t.Name}}
+	{{.Name}}: {{quote .Name}},
+{{- end}}{{end}}
+}
+
+func ({{$t.Recv}} {{$t.Name}}) String() string {
+	if s, ok := {{$t.Recv}}Names[{{$t.Recv}}]; ok {
+		return s
+	}
+	return fmt.Sprintf("UnknownTag(0x%04X)", uint16({{$t.Recv}}))
+}
+{{end}}
+var infos = []Info{
+{{- range .Rows}}
+	{ {{- ifd .IFD}}, {{hex .ID}}, {{quote .Name}}, []Type{ {{- join .Types ", "}}}, {{.Count}}, {{quote .Description}}},
+{{- end}}
+}
+`))
@@ -0,0 +1,351 @@
+package tag
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// printConv renders the decoded value of a tag, it returns false when
+// the value isn't the expected one and the raw formatting is used.
+type printConv func(v any) (string, bool)
+
+// printConvs are the print conversions of the tags, keyed by namespace and ID.
+var printConvs = map[Key]printConv{}
+
+func init() {
+	for t, conv := range map[Tag]printConv{
+		Orientation:               enumValue[OrientationValue],
+		ResolutionUnit:            enumValue[ResolutionUnitValue],
+		FocalPlaneResolutionUnit:  enumValue[ResolutionUnitValue],
+		YCbCrPositioning:          enumValue[YCbCrPositioningValue],
+		Compression:               enumValue[CompressionValue],
+		PhotometricInterpretation: enumValue[PhotometricInterpretationValue],
+		PlanarConfiguration:       enumValue[PlanarConfigurationValue],
+		ExposureProgram:           enumValue[ExposureProgramValue],
+		MeteringMode:              enumValue[MeteringModeValue],
+		LightSource:               enumValue[LightSourceValue],
+		Flash:                     enumValue[FlashValue],
+		SensingMethod:             enumValue[SensingMethodValue],
+		ColorSpace:                enumValue[ColorSpaceValue],
+		CustomRendered:            enumValue[CustomRenderedValue],
+		ExposureMode:              enumValue[ExposureModeValue],
+		WhiteBalance:              enumValue[WhiteBalanceValue],
+		SceneCaptureType:          enumValue[SceneCaptureTypeValue],
+		GainControl:               enumValue[GainControlValue],
+		Contrast:                  enumValue[ContrastValue],
+		Saturation:                enumValue[SaturationValue],
+		Sharpness:                 enumValue[SharpnessValue],
+		SubjectDistanceRange:      enumValue[SubjectDistanceRangeValue],
+		FileSource:                enumValue[FileSourceValue],
+		SceneType:                 enumValue[SceneTypeValue],
+
+		XResolution:             number(decimal),
+		YResolution:             number(decimal),
+		FocalPlaneXResolution:   number(decimal),
+		FocalPlaneYResolution:   number(decimal),
+		ExposureTime:            number(exposureTime),
+		FNumber:                 number(fNumber),
+		ApertureValue:           number(func(v float64) string { return fNumber(math.Pow(2, v/2)) }),
+		MaxApertureValue:        number(func(v float64) string { return fNumber(math.Pow(2, v/2)) }),
+		ShutterSpeedValue:       number(func(v float64) string { return exposureTime(math.Pow(2, -v)) }),
+		FocalLength:             number(func(v float64) string { return fmt.Sprintf("%.1f mm", v) }),
+		FocalLengthIn35mmFormat: number(func(v float64) string { return fmt.Sprintf("%.0f mm", v) }),
+		ExposureCompensation:    number(ev),
+		BrightnessValue:         number(ev),
+		SubjectDistance:         number(func(v float64) string { return fmt.Sprintf("%.2f m", v) }),
+		DigitalZoomRatio: number(func(v float64) string {
+			if v == 0 {
+				return "None"
+			}
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}),
+		ComponentsConfiguration: componentsConfiguration,
+		UserComment:             userComment,
+
+		GPSVersionID:        gpsVersion,
+		GPSLatitudeRef:      enumString(map[string]string{"N": "North", "S": "South"}),
+		GPSLatitude:         dms,
+		GPSLongitudeRef:     enumString(map[string]string{"E": "East", "W": "West"}),
+		GPSLongitude:        dms,
+		GPSAltitudeRef:      enum(map[int64]string{0: "Above Sea Level", 1: "Below Sea Level"}),
+		GPSAltitude:         number(func(v float64) string { return fmt.Sprintf("%.1f m", v) }),
+		GPSTimeStamp:        gpsTime,
+		GPSStatus:           enumString(map[string]string{"A": "Measurement Active", "V": "Measurement Void"}),
+		GPSMeasureMode:      enumString(map[string]string{"2": "2-Dimensional Measurement", "3": "3-Dimensional Measurement"}),
+		GPSSpeedRef:         enumString(map[string]string{"K": "km/h", "M": "mph", "N": "knots"}),
+		GPSTrackRef:         enumString(northNames),
+		GPSImgDirectionRef:  enumString(northNames),
+		GPSDestLatitudeRef:  enumString(map[string]string{"N": "North", "S": "South"}),
+		GPSDestLatitude:     dms,
+		GPSDestLongitudeRef: enumString(map[string]string{"E": "East", "W": "West"}),
+		GPSDestLongitude:    dms,
+		GPSDestBearingRef:   enumString(northNames),
+		GPSDestDistanceRef:  enumString(map[string]string{"K": "Kilometers", "M": "Miles", "N": "Nautical Miles"}),
+		GPSDifferential:     enum(map[int64]string{0: "No Correction", 1: "Differential Corrected"}),
+	} {
+		printConvs[t.Key()] = conv
+	}
+}
+
+// Format returns the value v of tag t, as decoded from an IFD, in a human
+// readable form: "Horizontal (normal)" for an Orientation of 1, "1/250 s"
+// for an ExposureTime of 1/250, "f/5.6"... Tags without print conversion,
+// or with an unexpected value, are formatted by FormatValue.
+func Format(t Tag, v any) string {
+	if conv, ok := printConvs[t.Key()]; ok {
+		if s, ok := conv(v); ok {
+			return s
+		}
+	}
+	return FormatValue(v)
+}
+
+// FormatValue returns a decoded value without print conversion: strings as is,
+// printable bytes as text, rationals in lowest terms, other values separated
+// by spaces.
+func FormatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		if s := strings.TrimRight(string(v), "\x00"); isPrintable(s) && s != "" {
+			return s
+		}
+		if len(v) > 16 {
+			return fmt.Sprintf("(binary data %d bytes)", len(v))
+		}
+	case Rational:
+		return v.Simplify().String()
+	case SRational:
+		return v.Simplify().String()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Sprint(v)
+	}
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = FormatValue(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, " ")
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// ints returns the values of an integer tag.
+func ints(v any) []int64 {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.Kind() != reflect.Slice {
+		rv = reflect.Append(reflect.MakeSlice(reflect.SliceOf(rv.Type()), 0, 1), rv)
+	}
+	out := make([]int64, rv.Len())
+	for i := range out {
+		switch e := rv.Index(i); {
+		case e.CanInt():
+			out[i] = e.Int()
+		case e.CanUint():
+			out[i] = int64(e.Uint())
+		default:
+			return nil
+		}
+	}
+	return out
+}
+
+// floats returns the values of a rational, float or integer tag.
+func floats(v any) []float64 {
+	switch v := v.(type) {
+	case []Rational:
+		out := make([]float64, len(v))
+		for i, r := range v {
+			out[i] = r.Float64()
+		}
+		return out
+	case []SRational:
+		out := make([]float64, len(v))
+		for i, r := range v {
+			out[i] = r.Float64()
+		}
+		return out
+	case []float32:
+		out := make([]float64, len(v))
+		for i, f := range v {
+			out[i] = float64(f)
+		}
+		return out
+	case []float64:
+		return v
+	}
+	var out []float64
+	for _, x := range ints(v) {
+		out = append(out, float64(x))
+	}
+	return out
+}
+
+// enum converts a single integer value with names.
+func enum(names map[int64]string) printConv {
+	return func(v any) (string, bool) {
+		i := ints(v)
+		if len(i) != 1 {
+			return "", false
+		}
+		if s, ok := names[i[0]]; ok {
+			return s, true
+		}
+		return fmt.Sprintf("Unknown (%d)", i[0]), true
+	}
+}
+
+// enumValue converts a single integer value with the String method of T.
+func enumValue[T interface {
+	~uint16
+	fmt.Stringer
+}](v any) (string, bool) {
+	i := ints(v)
+	if len(i) != 1 || i[0] < 0 || i[0] > math.MaxUint16 {
+		return "", false
+	}
+	return T(i[0]).String(), true
+}
+
+// enumString converts an ASCII value with names.
+func enumString(names map[string]string) printConv {
+	return func(v any) (string, bool) {
+		s, ok := v.(string)
+		if s = strings.TrimSpace(s); !ok || s == "" {
+			return "", false
+		}
+		if name, ok := names[s]; ok {
+			return name, true
+		}
+		return fmt.Sprintf("Unknown (%s)", s), true
+	}
+}
+
+// number converts a single numeric value with format,
+// undefined values (zero denominators) are left raw.
+func number(format func(float64) string) printConv {
+	return func(v any) (string, bool) {
+		f := floats(v)
+		if len(f) != 1 || math.IsNaN(f[0]) || math.IsInf(f[0], 0) {
+			return "", false
+		}
+		return format(f[0]), true
+	}
+}
+
+// exposureTime formats seconds, fractions below 1/4 s as 1/n.
+func exposureTime(s float64) string {
+	if s > 0 && s <= 0.25 {
+		return fmt.Sprintf("1/%.0f s", 1/s)
+	}
+	return strconv.FormatFloat(math.Round(s*10)/10, 'f', -1, 64) + " s"
+}
+
+// decimal formats a number without trailing zeros, e.g. 72 or 2.5.
+func decimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func fNumber(f float64) string {
+	return fmt.Sprintf("f/%.1f", f)
+}
+
+// ev formats an APEX value.
+func ev(v float64) string {
+	if math.Abs(v) < 0.05 {
+		return "0 EV"
+	}
+	return fmt.Sprintf("%+.1f EV", v)
+}
+
+// dms formats degrees, minutes and seconds.
+func dms(v any) (string, bool) {
+	f := floats(v)
+	if len(f) != 3 {
+		return "", false
+	}
+	return fmt.Sprintf("%.0f deg %.0f' %.2f\"", f[0], f[1], f[2]), true
+}
+
+// gpsTime formats hours, minutes and seconds.
+func gpsTime(v any) (string, bool) {
+	f := floats(v)
+	if len(f) != 3 {
+		return "", false
+	}
+	return fmt.Sprintf("%02.0f:%02.0f:%05.2f", f[0], f[1], f[2]), true
+}
+
+func gpsVersion(v any) (string, bool) {
+	b, ok := v.([]byte)
+	if !ok {
+		return "", false
+	}
+	parts := make([]string, len(b))
+	for i, x := range b {
+		parts[i] = strconv.Itoa(int(x))
+	}
+	return strings.Join(parts, "."), true
+}
+
+func componentsConfiguration(v any) (string, bool) {
+	b, ok := v.([]byte)
+	if !ok {
+		return "", false
+	}
+	names := []string{"-", "Y", "Cb", "Cr", "R", "G", "B"}
+	parts := make([]string, len(b))
+	for i, x := range b {
+		if int(x) < len(names) {
+			parts[i] = names[x]
+		} else {
+			parts[i] = strconv.Itoa(int(x))
+		}
+	}
+	return strings.Join(parts, ", "), true
+}
+
+// userComment drops the 8 bytes character code the comment starts with.
+func userComment(v any) (string, bool) {
+	b, ok := v.([]byte)
+	if !ok || len(b) < 8 {
+		return "", false
+	}
+	code, text := strings.TrimRight(string(b[:8]), "\x00 "), b[8:]
+	switch code {
+	case "ASCII", "":
+		return strings.TrimRight(string(text), "\x00 "), true
+	case "UNICODE":
+		// UCS-2, in the byte order of the TIFF header which isn't known
+		// here: ASCII characters are kept
+		var sb strings.Builder
+		for _, c := range text {
+			if c >= 0x20 && c < 0x7F {
+				sb.WriteByte(c)
+			}
+		}
+		return strings.TrimSpace(sb.String()), true
+	}
+	return "", false
+}
+
+var northNames = map[string]string{
+	"T": "True North",
+	"M": "Magnetic North",
+}
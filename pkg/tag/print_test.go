@@ -0,0 +1,81 @@
+package tag
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tag  Tag
+		v    any
+		want string
+	}{
+		{Orientation, []uint16{1}, "Horizontal (normal)"},
+		{Orientation, []uint16{42}, "Unknown (42)"},
+		{ResolutionUnit, []uint16{2}, "inches"},
+		{XResolution, []Rational{{1207959552, 16777216}}, "72"},
+		{YResolution, []Rational{{300, 1}}, "300"},
+		{FocalPlaneXResolution, []Rational{{5, 2}}, "2.5"},
+		{ExposureTime, []Rational{{1, 250}}, "1/250 s"},
+		{ExposureTime, []Rational{{10, 4}}, "2.5 s"},
+		{FNumber, []Rational{{56, 10}}, "f/5.6"},
+		{ApertureValue, []Rational{{4, 1}}, "f/4.0"},
+		{ShutterSpeedValue, []SRational{{8, 1}}, "1/256 s"},
+		{FocalLength, []Rational{{240, 10}}, "24.0 mm"},
+		{ExposureCompensation, []SRational{{-2, 3}}, "-0.7 EV"},
+		{ExposureCompensation, []SRational{{0, 1}}, "0 EV"},
+		{DigitalZoomRatio, []Rational{{0, 1}}, "None"},
+		{ComponentsConfiguration, []byte{1, 2, 3, 0}, "Y, Cb, Cr, -"},
+		{UserComment, []byte("ASCII\x00\x00\x00hello\x00"), "hello"},
+		{UserComment, []byte("UNICODE\x00h\x00i\x00"), "hi"},
+		{GPSVersionID, []byte{2, 3, 0, 0}, "2.3.0.0"},
+		{GPSLatitudeRef, "S", "South"},
+		{GPSLatitudeRef, "X", "Unknown (X)"},
+		{GPSLatitude, []Rational{{43, 1}, {28, 1}, {175, 100}}, "43 deg 28' 1.75\""},
+		{GPSAltitudeRef, []byte{1}, "Below Sea Level"},
+		{GPSAltitude, []Rational{{2105, 10}}, "210.5 m"},
+		{GPSTimeStamp, []Rational{{8, 1}, {1, 1}, {5, 2}}, "08:01:02.50"},
+		{GPSSpeedRef, "N", "knots"},
+
+		// unexpected values are formatted raw
+		{ExposureTime, []Rational{{1, 0}}, "1/0"},
+		{FNumber, "f/2", "f/2"},
+		{Orientation, []uint16{1, 2}, "1 2"},
+		{GPSLatitude, []Rational{{43, 1}}, "43"},
+
+		// no print conversion
+		{Make, "ACME", "ACME"},
+		{Software, []byte("v1.0\x00"), "v1.0"},
+		{ImageWidth, []uint32{4000}, "4000"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.tag, tt.v); got != tt.want {
+			t.Errorf("Format(%v, %#v) = %q, want %q", tt.tag, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{"text", "text"},
+		{[]byte("ASCII\x00\x00"), "ASCII"},
+		{[]byte{1, 2, 3}, "1 2 3"},
+		{make([]byte, 32), "(binary data 32 bytes)"},
+		{[]uint16{1, 2}, "1 2"},
+		{[]Rational{{1207959552, 16777216}, {10, 2500}}, "72 1/250"},
+		{[]SRational{{2, -6}}, "-1/3"},
+		{Rational{10, 20}, "1/2"},
+		{[]Rational{{3, 0}}, "1/0"},
+		{[]float64{math.Pi}, "3.141592653589793"},
+		{uint32(7), "7"},
+	}
+	for _, tt := range tests {
+		if got := FormatValue(tt.v); got != tt.want {
+			t.Errorf("FormatValue(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package tag
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Rational is the value of a RATIONAL field, two LONGs.
+type Rational struct {
+	Num uint32 `json:"num"`
+	Den uint32 `json:"den"`
+}
+
+// SRational is the value of a SRATIONAL field, two SLONGs.
+type SRational struct {
+	Num int32 `json:"num"`
+	Den int32 `json:"den"`
+}
+
+// NewRational returns num/den.
+func NewRational(num, den uint32) Rational {
+	return Rational{num, den}
+}
+
+// NewSRational returns num/den.
+func NewSRational(num, den int32) SRational {
+	return SRational{num, den}
+}
+
+// RationalFromFloat returns the closest rational to f, e.g. 1/250 for 0.004.
+// Negative values and NaN give 0/1, values above math.MaxUint32 give 1/0.
+func RationalFromFloat(f float64) Rational {
+	switch {
+	case math.IsNaN(f) || f <= 0:
+		return Rational{0, 1}
+	case f > math.MaxUint32:
+		return Rational{1, 0}
+	}
+	num, den := approximate(f, math.MaxUint32)
+	return Rational{uint32(num), uint32(den)}
+}
+
+// SRationalFromFloat returns the closest signed rational to f, e.g. -1/3
+// for -0.3333. NaN gives 0/1, values out of the int32 range give ±1/0.
+func SRationalFromFloat(f float64) SRational {
+	switch {
+	case math.IsNaN(f):
+		return SRational{0, 1}
+	case f > math.MaxInt32:
+		return SRational{1, 0}
+	case f < -math.MaxInt32:
+		return SRational{-1, 0}
+	}
+	num, den := approximate(math.Abs(f), math.MaxInt32)
+	if f < 0 {
+		return SRational{-int32(num), int32(den)}
+	}
+	return SRational{int32(num), int32(den)}
+}
+
+// approximate returns the continued fraction convergent of f >= 0 closest
+// to it whose numerator and denominator don't exceed limit.
+func approximate(f float64, limit uint64) (num, den uint64) {
+	// convergents h/k, starting with 1/0 and 0/1
+	h0, h1, k0, k1 := uint64(0), uint64(1), uint64(1), uint64(0)
+	x := f
+	for range 64 {
+		a := math.Floor(x)
+		if a > float64(limit) {
+			break
+		}
+		ai := uint64(a)
+		h, k := ai*h1+h0, ai*k1+k0
+		if h > limit || k > limit {
+			break
+		}
+		h0, h1, k0, k1 = h1, h, k1, k
+		if x-a < 1e-12 || math.Abs(float64(h)/float64(k)-f) <= 1e-12*f {
+			break
+		}
+		x = 1 / (x - a)
+	}
+	if k1 == 0 {
+		return 0, 1
+	}
+	return h1, k1
+}
+
+// IsValid reports whether r has a non-zero denominator.
+func (r Rational) IsValid() bool {
+	return r.Den != 0
+}
+
+// Float64 returns the value of r; +Inf for n/0 and NaN for 0/0.
+func (r Rational) Float64() float64 {
+	if r.Den == 0 {
+		if r.Num == 0 {
+			return math.NaN()
+		}
+		return math.Inf(1)
+	}
+	return float64(r.Num) / float64(r.Den)
+}
+
+// Simplify returns r in lowest terms, e.g. 1/250 for 10/2500.
+// n/0 gives 1/0 and 0/n gives 0/1.
+func (r Rational) Simplify() Rational {
+	if g := gcd(uint64(r.Num), uint64(r.Den)); g > 1 {
+		return Rational{r.Num / uint32(g), r.Den / uint32(g)}
+	}
+	return r
+}
+
+// Add returns r+q in lowest terms. Results that don't fit are approximated
+// as by RationalFromFloat, a zero denominator gives 0/0.
+func (r Rational) Add(q Rational) Rational {
+	if !r.IsValid() || !q.IsValid() {
+		return Rational{}
+	}
+	return ratFromBig(new(big.Rat).Add(r.big(), q.big()))
+}
+
+// Mul returns r*q in lowest terms. Results that don't fit are approximated
+// as by RationalFromFloat, a zero denominator gives 0/0.
+func (r Rational) Mul(q Rational) Rational {
+	if !r.IsValid() || !q.IsValid() {
+		return Rational{}
+	}
+	return ratFromBig(new(big.Rat).Mul(r.big(), q.big()))
+}
+
+// Cmp returns -1, 0 or +1 when r is less than, equal to or greater than q.
+// Zero denominators compare as their Float64 values do with cmp.Compare:
+// n/0 is +Inf and 0/0, NaN, is less than any other value.
+func (r Rational) Cmp(q Rational) int {
+	if !r.IsValid() || !q.IsValid() {
+		return cmp.Compare(r.Float64(), q.Float64())
+	}
+	return cmp.Compare(uint64(r.Num)*uint64(q.Den), uint64(q.Num)*uint64(r.Den))
+}
+
+func (r Rational) big() *big.Rat {
+	return new(big.Rat).SetFrac64(int64(r.Num), int64(r.Den))
+}
+
+func ratFromBig(x *big.Rat) Rational {
+	if num, den := x.Num(), x.Denom(); num.IsUint64() && num.Uint64() <= math.MaxUint32 && den.Uint64() <= math.MaxUint32 {
+		return Rational{uint32(num.Uint64()), uint32(den.Uint64())}
+	}
+	f, _ := x.Float64()
+	return RationalFromFloat(f)
+}
+
+// String returns r as a fraction, e.g. "1/250", or an integer when
+// its denominator is 1.
+func (r Rational) String() string {
+	if r.Den == 1 {
+		return strconv.FormatUint(uint64(r.Num), 10)
+	}
+	return fmt.Sprintf("%d/%d", r.Num, r.Den)
+}
+
+// Format formats r with the 'e', 'f' and 'g' verbs as a float, so that
+// fmt.Sprintf("f/%.1f", r) gives "f/2.8"; %#v gives the Go syntax and
+// other verbs use String.
+func (r Rational) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('#') {
+		fmt.Fprintf(s, "tag.Rational{Num:%#v, Den:%#v}", r.Num, r.Den)
+		return
+	}
+	formatRational(s, verb, r.Float64(), r.String())
+}
+
+// IsValid reports whether r has a non-zero denominator.
+func (r SRational) IsValid() bool {
+	return r.Den != 0
+}
+
+// Float64 returns the value of r; ±Inf for n/0 and NaN for 0/0.
+func (r SRational) Float64() float64 {
+	if r.Den == 0 {
+		if r.Num == 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(r.Num))
+	}
+	return float64(r.Num) / float64(r.Den)
+}
+
+// Simplify returns r in lowest terms with a positive denominator,
+// e.g. -1/3 for 2/-6.
+func (r SRational) Simplify() SRational {
+	if r.Den == math.MinInt32 {
+		// -MinInt32 doesn't fit: halve both terms, an odd numerator
+		// leaving r as is
+		if r.Num%2 != 0 {
+			return r
+		}
+		r = SRational{r.Num / 2, r.Den / 2}
+	}
+	num, den := int64(r.Num), int64(r.Den)
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := int64(gcd(uint64(abs(num)), uint64(den))); g > 1 {
+		num, den = num/g, den/g
+	}
+	if num > math.MaxInt32 {
+		// -MinInt32 doesn't fit
+		return r
+	}
+	return SRational{int32(num), int32(den)}
+}
+
+// Add returns r+q in lowest terms. Results that don't fit are approximated
+// as by SRationalFromFloat, a zero denominator gives 0/0.
+func (r SRational) Add(q SRational) SRational {
+	if !r.IsValid() || !q.IsValid() {
+		return SRational{}
+	}
+	return sratFromBig(new(big.Rat).Add(r.big(), q.big()))
+}
+
+// Mul returns r*q in lowest terms. Results that don't fit are approximated
+// as by SRationalFromFloat, a zero denominator gives 0/0.
+func (r SRational) Mul(q SRational) SRational {
+	if !r.IsValid() || !q.IsValid() {
+		return SRational{}
+	}
+	return sratFromBig(new(big.Rat).Mul(r.big(), q.big()))
+}
+
+// Cmp returns -1, 0 or +1 when r is less than, equal to or greater than q.
+// Zero denominators compare as their Float64 values do with cmp.Compare:
+// n/0 is ±Inf and 0/0, NaN, is less than any other value.
+func (r SRational) Cmp(q SRational) int {
+	if !r.IsValid() || !q.IsValid() {
+		return cmp.Compare(r.Float64(), q.Float64())
+	}
+	return r.big().Cmp(q.big())
+}
+
+func (r SRational) big() *big.Rat {
+	return new(big.Rat).SetFrac64(int64(r.Num), int64(r.Den))
+}
+
+func sratFromBig(x *big.Rat) SRational {
+	if num, den := x.Num(), x.Denom(); num.IsInt64() && num.Int64() >= math.MinInt32 && num.Int64() <= math.MaxInt32 && den.Int64() <= math.MaxInt32 {
+		return SRational{int32(num.Int64()), int32(den.Int64())}
+	}
+	f, _ := x.Float64()
+	return SRationalFromFloat(f)
+}
+
+// String returns r as a fraction, e.g. "-1/3", or an integer when
+// its denominator is 1.
+func (r SRational) String() string {
+	if r.Den == 1 {
+		return strconv.FormatInt(int64(r.Num), 10)
+	}
+	return fmt.Sprintf("%d/%d", r.Num, r.Den)
+}
+
+// Format formats r with the 'e', 'f' and 'g' verbs as a float, %#v gives
+// the Go syntax and other verbs use String.
+func (r SRational) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('#') {
+		fmt.Fprintf(s, "tag.SRational{Num:%#v, Den:%#v}", r.Num, r.Den)
+		return
+	}
+	formatRational(s, verb, r.Float64(), r.String())
+}
+
+func formatRational(s fmt.State, verb rune, f float64, str string) {
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), f)
+	default:
+		fmt.Fprintf(s, fmt.FormatString(s, 's'), str)
+	}
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
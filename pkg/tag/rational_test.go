@@ -0,0 +1,215 @@
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRationalSimplify(t *testing.T) {
+	tests := []struct {
+		r, want Rational
+	}{
+		{Rational{10, 2500}, Rational{1, 250}},
+		{Rational{1, 250}, Rational{1, 250}},
+		{Rational{1207959552, 16777216}, Rational{72, 1}},
+		{Rational{0, 5}, Rational{0, 1}},
+		{Rational{7, 0}, Rational{1, 0}},
+		{Rational{0, 0}, Rational{0, 0}},
+		{Rational{math.MaxUint32, math.MaxUint32}, Rational{1, 1}},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Simplify(); got != tt.want {
+			t.Errorf("%d/%d: Simplify() = %d/%d, want %d/%d", tt.r.Num, tt.r.Den, got.Num, got.Den, tt.want.Num, tt.want.Den)
+		}
+	}
+}
+
+func TestSRationalSimplify(t *testing.T) {
+	tests := []struct {
+		r, want SRational
+	}{
+		{SRational{2, -6}, SRational{-1, 3}},
+		{SRational{-2, -6}, SRational{1, 3}},
+		{SRational{-10, 4}, SRational{-5, 2}},
+		{SRational{0, -5}, SRational{0, 1}},
+		{SRational{-7, 0}, SRational{-1, 0}},
+		{SRational{0, 0}, SRational{0, 0}},
+		{SRational{4, math.MinInt32}, SRational{-1, 1 << 29}},
+		// -MinInt32 doesn't fit
+		{SRational{3, math.MinInt32}, SRational{3, math.MinInt32}},
+		{SRational{math.MinInt32, -1}, SRational{math.MinInt32, -1}},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Simplify(); got != tt.want {
+			t.Errorf("%d/%d: Simplify() = %d/%d, want %d/%d", tt.r.Num, tt.r.Den, got.Num, got.Den, tt.want.Num, tt.want.Den)
+		}
+	}
+}
+
+func TestRationalFromFloat(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want Rational
+	}{
+		{0.004, Rational{1, 250}},
+		{2.8, Rational{14, 5}},
+		{300, Rational{300, 1}},
+		{0, Rational{0, 1}},
+		{-1, Rational{0, 1}},
+		{math.NaN(), Rational{0, 1}},
+		{math.Inf(1), Rational{1, 0}},
+		{1e10, Rational{1, 0}},
+	}
+	for _, tt := range tests {
+		if got := RationalFromFloat(tt.f); got != tt.want {
+			t.Errorf("RationalFromFloat(%v) = %d/%d, want %d/%d", tt.f, got.Num, got.Den, tt.want.Num, tt.want.Den)
+		}
+	}
+
+	stests := []struct {
+		f    float64
+		want SRational
+	}{
+		{-1.0 / 3, SRational{-1, 3}},
+		{0.5, SRational{1, 2}},
+		{math.NaN(), SRational{0, 1}},
+		{math.Inf(1), SRational{1, 0}},
+		{math.Inf(-1), SRational{-1, 0}},
+		{-3e9, SRational{-1, 0}},
+	}
+	for _, tt := range stests {
+		if got := SRationalFromFloat(tt.f); got != tt.want {
+			t.Errorf("SRationalFromFloat(%v) = %d/%d, want %d/%d", tt.f, got.Num, got.Den, tt.want.Num, tt.want.Den)
+		}
+	}
+}
+
+func TestRationalZeroDenominator(t *testing.T) {
+	if r := (Rational{1, 0}); r.IsValid() || !math.IsInf(r.Float64(), 1) {
+		t.Errorf("1/0: IsValid() %t, Float64() %v", r.IsValid(), r.Float64())
+	}
+	if r := (Rational{}); r.IsValid() || !math.IsNaN(r.Float64()) {
+		t.Errorf("0/0: IsValid() %t, Float64() %v", r.IsValid(), r.Float64())
+	}
+	if r := (SRational{-1, 0}); r.IsValid() || !math.IsInf(r.Float64(), -1) {
+		t.Errorf("-1/0: IsValid() %t, Float64() %v", r.IsValid(), r.Float64())
+	}
+	if got := (Rational{1, 2}).Add(Rational{1, 0}); got != (Rational{}) {
+		t.Errorf("1/2 + 1/0 = %v, want 0/0", got)
+	}
+	if got := (SRational{1, 2}).Mul(SRational{0, 0}); got != (SRational{}) {
+		t.Errorf("1/2 * 0/0 = %v, want 0/0", got)
+	}
+	if got := fmt.Sprint(Rational{1, 0}); got != "1/0" {
+		t.Errorf("Sprint(1/0) = %q", got)
+	}
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	tests := []struct {
+		r, q, sum, product Rational
+		cmp                int
+	}{
+		{Rational{1, 2}, Rational{1, 3}, Rational{5, 6}, Rational{1, 6}, 1},
+		{Rational{1, 250}, Rational{2, 500}, Rational{1, 125}, Rational{1, 62500}, 0},
+		{Rational{0, 1}, Rational{3, 4}, Rational{3, 4}, Rational{0, 1}, -1},
+		{Rational{math.MaxUint32, 1}, Rational{math.MaxUint32, 1}, Rational{1, 0}, Rational{1, 0}, 0},
+		{Rational{1, math.MaxUint32}, Rational{1, math.MaxUint32 - 1}, RationalFromFloat(1.0/math.MaxUint32 + 1.0/(math.MaxUint32-1)), Rational{0, 1}, -1},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Add(tt.q); got != tt.sum {
+			t.Errorf("%v + %v = %v, want %v", tt.r, tt.q, got, tt.sum)
+		}
+		if got := tt.r.Mul(tt.q); got != tt.product {
+			t.Errorf("%v * %v = %v, want %v", tt.r, tt.q, got, tt.product)
+		}
+		if got := tt.r.Cmp(tt.q); got != tt.cmp {
+			t.Errorf("%v.Cmp(%v) = %d, want %d", tt.r, tt.q, got, tt.cmp)
+		}
+		if got := tt.q.Cmp(tt.r); got != -tt.cmp {
+			t.Errorf("%v.Cmp(%v) = %d, want %d", tt.q, tt.r, got, -tt.cmp)
+		}
+	}
+
+	stests := []struct {
+		r, q, sum, product SRational
+		cmp                int
+	}{
+		{SRational{1, 2}, SRational{-1, 3}, SRational{1, 6}, SRational{-1, 6}, 1},
+		{SRational{2, -6}, SRational{-1, 3}, SRational{-2, 3}, SRational{1, 9}, 0},
+		{SRational{-1, 2}, SRational{1, -2}, SRational{-1, 1}, SRational{1, 4}, 0},
+		{SRational{math.MaxInt32, 1}, SRational{1, 1}, SRational{1, 0}, SRational{math.MaxInt32, 1}, 1},
+		{SRational{math.MinInt32, 1}, SRational{-1, 1}, SRational{-1, 0}, SRational{1, 0}, -1},
+	}
+	for _, tt := range stests {
+		if got := tt.r.Add(tt.q); got != tt.sum {
+			t.Errorf("%v + %v = %v, want %v", tt.r, tt.q, got, tt.sum)
+		}
+		if got := tt.r.Mul(tt.q); got != tt.product {
+			t.Errorf("%v * %v = %v, want %v", tt.r, tt.q, got, tt.product)
+		}
+		if got := tt.r.Cmp(tt.q); got != tt.cmp {
+			t.Errorf("%v.Cmp(%v) = %d, want %d", tt.r, tt.q, got, tt.cmp)
+		}
+	}
+
+	// zero denominators compare as floats
+	for _, tt := range []struct {
+		r, q Rational
+		want int
+	}{
+		{Rational{1, 0}, Rational{math.MaxUint32, 1}, 1},
+		{Rational{1, 0}, Rational{2, 0}, 0},
+		{Rational{0, 0}, Rational{0, 1}, -1},
+		{Rational{0, 0}, Rational{0, 0}, 0},
+	} {
+		if got := tt.r.Cmp(tt.q); got != tt.want {
+			t.Errorf("%v.Cmp(%v) = %d, want %d", tt.r, tt.q, got, tt.want)
+		}
+	}
+	if got := (SRational{-1, 0}).Cmp(SRational{math.MinInt32, 1}); got != -1 {
+		t.Errorf("-1/0 Cmp MinInt32 = %d, want -1", got)
+	}
+}
+
+func TestRationalFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		v      any
+		want   string
+	}{
+		{"%v", Rational{1, 250}, "1/250"},
+		{"%s", Rational{300, 1}, "300"},
+		{"f/%.1f", Rational{28, 10}, "f/2.8"},
+		{"%g", SRational{-1, 3}, "-0.3333333333333333"},
+		{"%8v|", SRational{-1, 3}, "    -1/3|"},
+		{"%#v", Rational{1, 250}, "tag.Rational{Num:0x1, Den:0xfa}"},
+		{"%#v", SRational{-1, 3}, "tag.SRational{Num:-1, Den:3}"},
+		{"%#v", []Rational{{1, 2}}, "[]tag.Rational{tag.Rational{Num:0x1, Den:0x2}}"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.v); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRationalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		R Rational
+		S SRational
+	}{Rational{1, 250}, SRational{-1, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"R":{"num":1,"den":250},"S":{"num":-1,"den":3}}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	var r Rational
+	if err := json.Unmarshal([]byte(`{"num":28,"den":10}`), &r); err != nil || r != (Rational{28, 10}) {
+		t.Errorf("got %v, %v", r, err)
+	}
+}
@@ -0,0 +1,107 @@
+package tag
+
+//go:generate go run ./internal/gen -in tags.csv -out tags_gen.go
+
+import "fmt"
+
+// IFD is the namespace a tag ID belongs to. Tag IDs are only unique
+// within one namespace: 0x0001 is GPSLatitudeRef in the GPS IFD but
+// InteropIndex in the Interoperability IFD.
+type IFD uint8
+
+const (
+	// IFD0, IFD1 and every other IFD of the main chain
+	IFDTIFF IFD = iota + 1
+
+	// Exif SubIFD, pointed to by ExifOffset (0x8769)
+	IFDExif
+
+	// GPS IFD, pointed to by GPSInfo (0x8825)
+	IFDGPS
+
+	// Interoperability IFD, pointed to by InteropOffset (0xA005)
+	IFDInterop
+)
+
+var ifdNames = map[IFD]string{
+	IFDTIFF:    "TIFF",
+	IFDExif:    "Exif",
+	IFDGPS:     "GPS",
+	IFDInterop: "Interop",
+}
+
+func (n IFD) String() string {
+	if s, ok := ifdNames[n]; ok {
+		return s
+	}
+	return fmt.Sprintf("IFD(%d)", uint8(n))
+}
+
+// Info describes a tag of the registry.
+type Info struct {
+	IFD  IFD
+	ID   uint16
+	Name string
+
+	// field types allowed by the spec, most common first
+	Types []Type
+
+	// number of values, 0 when it's variable (e.g. ASCII strings)
+	Count uint32
+
+	Description string
+}
+
+// Key identifies a tag across namespaces.
+type Key struct {
+	IFD IFD
+	ID  uint16
+}
+
+// Key returns k, so that a Key is a Tag.
+func (k Key) Key() Key {
+	return k
+}
+
+func (k Key) String() string {
+	return Name(k.IFD, k.ID)
+}
+
+// registry and byName index infos, the table generated from tags.csv.
+// They are built as package variables, before the init functions that
+// may look tags up run.
+var registry, byName = index(infos)
+
+func index(infos []Info) (map[Key]Info, map[IFD]map[string]Info) {
+	registry := make(map[Key]Info, len(infos))
+	byName := make(map[IFD]map[string]Info)
+	for _, info := range infos {
+		registry[Key{info.IFD, info.ID}] = info
+		if byName[info.IFD] == nil {
+			byName[info.IFD] = make(map[string]Info)
+		}
+		byName[info.IFD][info.Name] = info
+	}
+	return registry, byName
+}
+
+// Lookup returns the registry entry of tag id in the ifd namespace.
+func Lookup(ifd IFD, id uint16) (Info, bool) {
+	info, ok := registry[Key{ifd, id}]
+	return info, ok
+}
+
+// LookupName returns the registry entry of the tag called name in the ifd namespace.
+func LookupName(ifd IFD, name string) (Info, bool) {
+	info, ok := byName[ifd][name]
+	return info, ok
+}
+
+// Name returns the name of tag id in the ifd namespace,
+// or "UnknownTag(0xXXXX)" when it's not registered.
+func Name(ifd IFD, id uint16) string {
+	if info, ok := registry[Key{ifd, id}]; ok {
+		return info.Name
+	}
+	return fmt.Sprintf("UnknownTag(0x%04X)", id)
+}
@@ -1,5 +1,7 @@
 package tag
 
+import "fmt"
+
 type Type uint16
 
 const (
@@ -15,6 +17,15 @@ const (
 	SRATIONAL Type = 0x0A
 	FLOAT     Type = 0x0B
 	DOUBLE    Type = 0x0C
+
+	// offset of a sub-IFD, otherwise a LONG (IFD in the TIFF specs,
+	// renamed as IFD is the tag namespace)
+	IFD4 Type = 0x0D
+
+	// BigTIFF types
+	LONG8  Type = 0x10
+	SLONG8 Type = 0x11
+	IFD8   Type = 0x12
 )
 
 var TypeSizes = map[Type]uint16{
@@ -30,4 +41,34 @@ var TypeSizes = map[Type]uint16{
 	SRATIONAL: 8, // two SLONGs (2 x 4 bytes)
 	FLOAT:     4,
 	DOUBLE:    8,
+	IFD4:      4,
+	LONG8:     8,
+	SLONG8:    8,
+	IFD8:      8,
+}
+
+var typeNames = map[Type]string{
+	BYTE:      "BYTE",
+	ASCII:     "ASCII",
+	SHORT:     "SHORT",
+	LONG:      "LONG",
+	RATIONAL:  "RATIONAL",
+	SBYTE:     "SBYTE",
+	UNDEFINED: "UNDEFINED",
+	SSHORT:    "SSHORT",
+	SLONG:     "SLONG",
+	SRATIONAL: "SRATIONAL",
+	FLOAT:     "FLOAT",
+	DOUBLE:    "DOUBLE",
+	IFD4:      "IFD",
+	LONG8:     "LONG8",
+	SLONG8:    "SLONG8",
+	IFD8:      "IFD8",
+}
+
+func (t Type) String() string {
+	if s, ok := typeNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("Type(%d)", uint16(t))
 }
@@ -0,0 +1,995 @@
+// Code generated by "go run ./internal/gen -in tags.csv"; DO NOT EDIT.
+
+package tag
+
+import "fmt"
+
+const (
+	ProcessingSoftware          Exif = 0x000B
+	SubfileType                 Exif = 0x00FE
+	OldSubfileType              Exif = 0x00FF
+	ImageWidth                  Exif = 0x0100
+	ImageHeight                 Exif = 0x0101
+	BitsPerSample               Exif = 0x0102
+	Compression                 Exif = 0x0103
+	PhotometricInterpretation   Exif = 0x0106
+	Thresholding                Exif = 0x0107
+	CellWidth                   Exif = 0x0108
+	CellLength                  Exif = 0x0109
+	FillOrder                   Exif = 0x010A
+	DocumentName                Exif = 0x010D
+	ImageDescription            Exif = 0x010E
+	Make                        Exif = 0x010F
+	Model                       Exif = 0x0110
+	StripOffsets                Exif = 0x0111
+	Orientation                 Exif = 0x0112
+	SamplesPerPixel             Exif = 0x0115
+	RowsPerStrip                Exif = 0x0116
+	StripByteCounts             Exif = 0x0117
+	MinSampleValue              Exif = 0x0118
+	MaxSampleValue              Exif = 0x0119
+	XResolution                 Exif = 0x011A
+	YResolution                 Exif = 0x011B
+	PlanarConfiguration         Exif = 0x011C
+	PageName                    Exif = 0x011D
+	XPosition                   Exif = 0x011E
+	YPosition                   Exif = 0x011F
+	FreeOffsets                 Exif = 0x0120
+	FreeByteCounts              Exif = 0x0121
+	GrayResponseUnit            Exif = 0x0122
+	GrayResponseCurve           Exif = 0x0123
+	T4Options                   Exif = 0x0124
+	T6Options                   Exif = 0x0125
+	ResolutionUnit              Exif = 0x0128
+	PageNumber                  Exif = 0x0129
+	TransferFunction            Exif = 0x012D
+	Software                    Exif = 0x0131
+	ModifyDate                  Exif = 0x0132
+	Artist                      Exif = 0x013B
+	HostComputer                Exif = 0x013C
+	Predictor                   Exif = 0x013D
+	WhitePoint                  Exif = 0x013E
+	PrimaryChromaticities       Exif = 0x013F
+	ColorMap                    Exif = 0x0140
+	HalftoneHints               Exif = 0x0141
+	TileWidth                   Exif = 0x0142
+	TileLength                  Exif = 0x0143
+	TileOffsets                 Exif = 0x0144
+	TileByteCounts              Exif = 0x0145
+	SubIFD                      Exif = 0x014A
+	InkSet                      Exif = 0x014C
+	InkNames                    Exif = 0x014D
+	NumberofInks                Exif = 0x014E
+	DotRange                    Exif = 0x0150
+	TargetPrinter               Exif = 0x0151
+	ExtraSamples                Exif = 0x0152
+	SampleFormat                Exif = 0x0153
+	SMinSampleValue             Exif = 0x0154
+	SMaxSampleValue             Exif = 0x0155
+	TransferRange               Exif = 0x0156
+	ClipPath                    Exif = 0x0157
+	XClipPathUnits              Exif = 0x0158
+	YClipPathUnits              Exif = 0x0159
+	Indexed                     Exif = 0x015A
+	JPEGTables                  Exif = 0x015B
+	OPIProxy                    Exif = 0x015F
+	JPEGProc                    Exif = 0x0200
+	ThumbnailOffset             Exif = 0x0201
+	ThumbnailLength             Exif = 0x0202
+	JPEGRestartInterval         Exif = 0x0203
+	JPEGLosslessPredictors      Exif = 0x0205
+	JPEGPointTransforms         Exif = 0x0206
+	JPEGQTables                 Exif = 0x0207
+	JPEGDCTables                Exif = 0x0208
+	JPEGACTables                Exif = 0x0209
+	YCbCrCoefficients           Exif = 0x0211
+	YCbCrSubSampling            Exif = 0x0212
+	YCbCrPositioning            Exif = 0x0213
+	ReferenceBlackWhite         Exif = 0x0214
+	ApplicationNotes            Exif = 0x02BC
+	Rating                      Exif = 0x4746
+	RatingPercent               Exif = 0x4749
+	ImageID                     Exif = 0x800D
+	CFARepeatPatternDim         Exif = 0x828D
+	CFAPattern2                 Exif = 0x828E
+	BatteryLevel                Exif = 0x828F
+	Copyright                   Exif = 0x8298
+	IPTCNAA                     Exif = 0x83BB
+	PhotoshopSettings           Exif = 0x8649
+	ExifOffset                  Exif = 0x8769
+	ICC_Profile                 Exif = 0x8773
+	GPSInfo                     Exif = 0x8825
+	TimeZoneOffset              Exif = 0x882A
+	SelfTimerMode               Exif = 0x882B
+	ImageNumber                 Exif = 0x9211
+	SecurityClassification      Exif = 0x9212
+	ImageHistory                Exif = 0x9213
+	TIFF_EPStandardID           Exif = 0x9216
+	ImageSourceData             Exif = 0x935C
+	XPTitle                     Exif = 0x9C9B
+	XPComment                   Exif = 0x9C9C
+	XPAuthor                    Exif = 0x9C9D
+	XPKeywords                  Exif = 0x9C9E
+	XPSubject                   Exif = 0x9C9F
+	GDALMetadata                Exif = 0xA480
+	GDALNoData                  Exif = 0xA481
+	PrintIM                     Exif = 0xC4A5
+	DNGVersion                  Exif = 0xC612
+	DNGBackwardVersion          Exif = 0xC613
+	UniqueCameraModel           Exif = 0xC614
+	LocalizedCameraModel        Exif = 0xC615
+	CFAPlaneColor               Exif = 0xC616
+	CFALayout                   Exif = 0xC617
+	LinearizationTable          Exif = 0xC618
+	BlackLevelRepeatDim         Exif = 0xC619
+	BlackLevel                  Exif = 0xC61A
+	BlackLevelDeltaH            Exif = 0xC61B
+	BlackLevelDeltaV            Exif = 0xC61C
+	WhiteLevel                  Exif = 0xC61D
+	DefaultScale                Exif = 0xC61E
+	DefaultCropOrigin           Exif = 0xC61F
+	DefaultCropSize             Exif = 0xC620
+	ColorMatrix1                Exif = 0xC621
+	ColorMatrix2                Exif = 0xC622
+	CameraCalibration1          Exif = 0xC623
+	CameraCalibration2          Exif = 0xC624
+	ReductionMatrix1            Exif = 0xC625
+	ReductionMatrix2            Exif = 0xC626
+	AnalogBalance               Exif = 0xC627
+	AsShotNeutral               Exif = 0xC628
+	AsShotWhiteXY               Exif = 0xC629
+	BaselineExposure            Exif = 0xC62A
+	BaselineNoise               Exif = 0xC62B
+	BaselineSharpness           Exif = 0xC62C
+	BayerGreenSplit             Exif = 0xC62D
+	LinearResponseLimit         Exif = 0xC62E
+	CameraSerialNumber          Exif = 0xC62F
+	DNGLensInfo                 Exif = 0xC630
+	ChromaBlurRadius            Exif = 0xC631
+	AntiAliasStrength           Exif = 0xC632
+	ShadowScale                 Exif = 0xC633
+	DNGPrivateData              Exif = 0xC634
+	MakerNoteSafety             Exif = 0xC635
+	CalibrationIlluminant1      Exif = 0xC65A
+	CalibrationIlluminant2      Exif = 0xC65B
+	BestQualityScale            Exif = 0xC65C
+	RawDataUniqueID             Exif = 0xC65D
+	OriginalRawFileName         Exif = 0xC68B
+	OriginalRawFileData         Exif = 0xC68C
+	ActiveArea                  Exif = 0xC68D
+	MaskedAreas                 Exif = 0xC68E
+	AsShotICCProfile            Exif = 0xC68F
+	AsShotPreProfileMatrix      Exif = 0xC690
+	CurrentICCProfile           Exif = 0xC691
+	CurrentPreProfileMatrix     Exif = 0xC692
+	ColorimetricReference       Exif = 0xC6BF
+	CameraCalibrationSig        Exif = 0xC6F3
+	ProfileCalibrationSig       Exif = 0xC6F4
+	ProfileIFD                  Exif = 0xC6F5
+	AsShotProfileName           Exif = 0xC6F6
+	NoiseReductionApplied       Exif = 0xC6F7
+	ProfileName                 Exif = 0xC6F8
+	ProfileHueSatMapDims        Exif = 0xC6F9
+	ProfileHueSatMapData1       Exif = 0xC6FA
+	ProfileHueSatMapData2       Exif = 0xC6FB
+	ProfileToneCurve            Exif = 0xC6FC
+	ProfileEmbedPolicy          Exif = 0xC6FD
+	ProfileCopyright            Exif = 0xC6FE
+	ForwardMatrix1              Exif = 0xC714
+	ForwardMatrix2              Exif = 0xC715
+	PreviewApplicationName      Exif = 0xC716
+	PreviewApplicationVersion   Exif = 0xC717
+	PreviewSettingsName         Exif = 0xC718
+	PreviewSettingsDigest       Exif = 0xC719
+	PreviewColorSpace           Exif = 0xC71A
+	PreviewDateTime             Exif = 0xC71B
+	RawImageDigest              Exif = 0xC71C
+	OriginalRawFileDigest       Exif = 0xC71D
+	SubTileBlockSize            Exif = 0xC71E
+	RowInterleaveFactor         Exif = 0xC71F
+	ProfileLookTableDims        Exif = 0xC725
+	ProfileLookTableData        Exif = 0xC726
+	OpcodeList1                 Exif = 0xC740
+	OpcodeList2                 Exif = 0xC741
+	OpcodeList3                 Exif = 0xC74E
+	NoiseProfile                Exif = 0xC761
+	Padding                     Exif = 0xEA1C
+	ExposureTime                Exif = 0x829A
+	FNumber                     Exif = 0x829D
+	ExposureProgram             Exif = 0x8822
+	SpectralSensitivity         Exif = 0x8824
+	ISO                         Exif = 0x8827
+	OECF                        Exif = 0x8828
+	SensitivityType             Exif = 0x8830
+	StandardOutputSensitivity   Exif = 0x8831
+	RecommendedExposureIndex    Exif = 0x8832
+	ISOSpeed                    Exif = 0x8833
+	ISOSpeedLatitudeyyy         Exif = 0x8834
+	ISOSpeedLatitudezzz         Exif = 0x8835
+	ExifVersion                 Exif = 0x9000
+	DateTimeOriginal            Exif = 0x9003
+	CreateDate                  Exif = 0x9004
+	OffsetTime                  Exif = 0x9010
+	OffsetTimeOriginal          Exif = 0x9011
+	OffsetTimeDigitized         Exif = 0x9012
+	ComponentsConfiguration     Exif = 0x9101
+	CompressedBitsPerPixel      Exif = 0x9102
+	ShutterSpeedValue           Exif = 0x9201
+	ApertureValue               Exif = 0x9202
+	BrightnessValue             Exif = 0x9203
+	ExposureCompensation        Exif = 0x9204
+	MaxApertureValue            Exif = 0x9205
+	SubjectDistance             Exif = 0x9206
+	MeteringMode                Exif = 0x9207
+	LightSource                 Exif = 0x9208
+	Flash                       Exif = 0x9209
+	FocalLength                 Exif = 0x920A
+	SubjectArea                 Exif = 0x9214
+	MakerNote                   Exif = 0x927C
+	UserComment                 Exif = 0x9286
+	SubSecTime                  Exif = 0x9290
+	SubSecTimeOriginal          Exif = 0x9291
+	SubSecTimeDigitized         Exif = 0x9292
+	AmbientTemperature          Exif = 0x9400
+	Humidity                    Exif = 0x9401
+	Pressure                    Exif = 0x9402
+	WaterDepth                  Exif = 0x9403
+	Acceleration                Exif = 0x9404
+	CameraElevationAngle        Exif = 0x9405
+	FlashpixVersion             Exif = 0xA000
+	ColorSpace                  Exif = 0xA001
+	ExifImageWidth              Exif = 0xA002
+	ExifImageHeight             Exif = 0xA003
+	RelatedSoundFile            Exif = 0xA004
+	InteropOffset               Exif = 0xA005
+	FlashEnergy                 Exif = 0xA20B
+	SpatialFrequencyResponse    Exif = 0xA20C
+	FocalPlaneXResolution       Exif = 0xA20E
+	FocalPlaneYResolution       Exif = 0xA20F
+	FocalPlaneResolutionUnit    Exif = 0xA210
+	SubjectLocation             Exif = 0xA214
+	ExposureIndex               Exif = 0xA215
+	SensingMethod               Exif = 0xA217
+	FileSource                  Exif = 0xA300
+	SceneType                   Exif = 0xA301
+	CFAPattern                  Exif = 0xA302
+	CustomRendered              Exif = 0xA401
+	ExposureMode                Exif = 0xA402
+	WhiteBalance                Exif = 0xA403
+	DigitalZoomRatio            Exif = 0xA404
+	FocalLengthIn35mmFormat     Exif = 0xA405
+	SceneCaptureType            Exif = 0xA406
+	GainControl                 Exif = 0xA407
+	Contrast                    Exif = 0xA408
+	Saturation                  Exif = 0xA409
+	Sharpness                   Exif = 0xA40A
+	DeviceSettingDescription    Exif = 0xA40B
+	SubjectDistanceRange        Exif = 0xA40C
+	ImageUniqueID               Exif = 0xA420
+	OwnerName                   Exif = 0xA430
+	SerialNumber                Exif = 0xA431
+	LensInfo                    Exif = 0xA432
+	LensMake                    Exif = 0xA433
+	LensModel                   Exif = 0xA434
+	LensSerialNumber            Exif = 0xA435
+	ImageTitle                  Exif = 0xA436
+	Photographer                Exif = 0xA437
+	ImageEditor                 Exif = 0xA438
+	CameraFirmware              Exif = 0xA439
+	RAWDevelopingSoftware       Exif = 0xA43A
+	ImageEditingSoftware        Exif = 0xA43B
+	MetadataEditingSoftware     Exif = 0xA43C
+	CompositeImage              Exif = 0xA460
+	CompositeImageCount         Exif = 0xA461
+	CompositeImageExposureTimes Exif = 0xA462
+	Gamma                       Exif = 0xA500
+	OffsetSchema                Exif = 0xEA1D
+)
+
+var eNames = map[Exif]string{
+	ProcessingSoftware:          "ProcessingSoftware",
+	SubfileType:                 "SubfileType",
+	OldSubfileType:              "OldSubfileType",
+	ImageWidth:                  "ImageWidth",
+	ImageHeight:                 "ImageHeight",
+	BitsPerSample:               "BitsPerSample",
+	Compression:                 "Compression",
+	PhotometricInterpretation:   "PhotometricInterpretation",
+	Thresholding:                "Thresholding",
+	CellWidth:                   "CellWidth",
+	CellLength:                  "CellLength",
+	FillOrder:                   "FillOrder",
+	DocumentName:                "DocumentName",
+	ImageDescription:            "ImageDescription",
+	Make:                        "Make",
+	Model:                       "Model",
+	StripOffsets:                "StripOffsets",
+	Orientation:                 "Orientation",
+	SamplesPerPixel:             "SamplesPerPixel",
+	RowsPerStrip:                "RowsPerStrip",
+	StripByteCounts:             "StripByteCounts",
+	MinSampleValue:              "MinSampleValue",
+	MaxSampleValue:              "MaxSampleValue",
+	XResolution:                 "XResolution",
+	YResolution:                 "YResolution",
+	PlanarConfiguration:         "PlanarConfiguration",
+	PageName:                    "PageName",
+	XPosition:                   "XPosition",
+	YPosition:                   "YPosition",
+	FreeOffsets:                 "FreeOffsets",
+	FreeByteCounts:              "FreeByteCounts",
+	GrayResponseUnit:            "GrayResponseUnit",
+	GrayResponseCurve:           "GrayResponseCurve",
+	T4Options:                   "T4Options",
+	T6Options:                   "T6Options",
+	ResolutionUnit:              "ResolutionUnit",
+	PageNumber:                  "PageNumber",
+	TransferFunction:            "TransferFunction",
+	Software:                    "Software",
+	ModifyDate:                  "ModifyDate",
+	Artist:                      "Artist",
+	HostComputer:                "HostComputer",
+	Predictor:                   "Predictor",
+	WhitePoint:                  "WhitePoint",
+	PrimaryChromaticities:       "PrimaryChromaticities",
+	ColorMap:                    "ColorMap",
+	HalftoneHints:               "HalftoneHints",
+	TileWidth:                   "TileWidth",
+	TileLength:                  "TileLength",
+	TileOffsets:                 "TileOffsets",
+	TileByteCounts:              "TileByteCounts",
+	SubIFD:                      "SubIFD",
+	InkSet:                      "InkSet",
+	InkNames:                    "InkNames",
+	NumberofInks:                "NumberofInks",
+	DotRange:                    "DotRange",
+	TargetPrinter:               "TargetPrinter",
+	ExtraSamples:                "ExtraSamples",
+	SampleFormat:                "SampleFormat",
+	SMinSampleValue:             "SMinSampleValue",
+	SMaxSampleValue:             "SMaxSampleValue",
+	TransferRange:               "TransferRange",
+	ClipPath:                    "ClipPath",
+	XClipPathUnits:              "XClipPathUnits",
+	YClipPathUnits:              "YClipPathUnits",
+	Indexed:                     "Indexed",
+	JPEGTables:                  "JPEGTables",
+	OPIProxy:                    "OPIProxy",
+	JPEGProc:                    "JPEGProc",
+	ThumbnailOffset:             "ThumbnailOffset",
+	ThumbnailLength:             "ThumbnailLength",
+	JPEGRestartInterval:         "JPEGRestartInterval",
+	JPEGLosslessPredictors:      "JPEGLosslessPredictors",
+	JPEGPointTransforms:         "JPEGPointTransforms",
+	JPEGQTables:                 "JPEGQTables",
+	JPEGDCTables:                "JPEGDCTables",
+	JPEGACTables:                "JPEGACTables",
+	YCbCrCoefficients:           "YCbCrCoefficients",
+	YCbCrSubSampling:            "YCbCrSubSampling",
+	YCbCrPositioning:            "YCbCrPositioning",
+	ReferenceBlackWhite:         "ReferenceBlackWhite",
+	ApplicationNotes:            "ApplicationNotes",
+	Rating:                      "Rating",
+	RatingPercent:               "RatingPercent",
+	ImageID:                     "ImageID",
+	CFARepeatPatternDim:         "CFARepeatPatternDim",
+	CFAPattern2:                 "CFAPattern2",
+	BatteryLevel:                "BatteryLevel",
+	Copyright:                   "Copyright",
+	IPTCNAA:                     "IPTCNAA",
+	PhotoshopSettings:           "PhotoshopSettings",
+	ExifOffset:                  "ExifOffset",
+	ICC_Profile:                 "ICC_Profile",
+	GPSInfo:                     "GPSInfo",
+	TimeZoneOffset:              "TimeZoneOffset",
+	SelfTimerMode:               "SelfTimerMode",
+	ImageNumber:                 "ImageNumber",
+	SecurityClassification:      "SecurityClassification",
+	ImageHistory:                "ImageHistory",
+	TIFF_EPStandardID:           "TIFF_EPStandardID",
+	ImageSourceData:             "ImageSourceData",
+	XPTitle:                     "XPTitle",
+	XPComment:                   "XPComment",
+	XPAuthor:                    "XPAuthor",
+	XPKeywords:                  "XPKeywords",
+	XPSubject:                   "XPSubject",
+	GDALMetadata:                "GDALMetadata",
+	GDALNoData:                  "GDALNoData",
+	PrintIM:                     "PrintIM",
+	DNGVersion:                  "DNGVersion",
+	DNGBackwardVersion:          "DNGBackwardVersion",
+	UniqueCameraModel:           "UniqueCameraModel",
+	LocalizedCameraModel:        "LocalizedCameraModel",
+	CFAPlaneColor:               "CFAPlaneColor",
+	CFALayout:                   "CFALayout",
+	LinearizationTable:          "LinearizationTable",
+	BlackLevelRepeatDim:         "BlackLevelRepeatDim",
+	BlackLevel:                  "BlackLevel",
+	BlackLevelDeltaH:            "BlackLevelDeltaH",
+	BlackLevelDeltaV:            "BlackLevelDeltaV",
+	WhiteLevel:                  "WhiteLevel",
+	DefaultScale:                "DefaultScale",
+	DefaultCropOrigin:           "DefaultCropOrigin",
+	DefaultCropSize:             "DefaultCropSize",
+	ColorMatrix1:                "ColorMatrix1",
+	ColorMatrix2:                "ColorMatrix2",
+	CameraCalibration1:          "CameraCalibration1",
+	CameraCalibration2:          "CameraCalibration2",
+	ReductionMatrix1:            "ReductionMatrix1",
+	ReductionMatrix2:            "ReductionMatrix2",
+	AnalogBalance:               "AnalogBalance",
+	AsShotNeutral:               "AsShotNeutral",
+	AsShotWhiteXY:               "AsShotWhiteXY",
+	BaselineExposure:            "BaselineExposure",
+	BaselineNoise:               "BaselineNoise",
+	BaselineSharpness:           "BaselineSharpness",
+	BayerGreenSplit:             "BayerGreenSplit",
+	LinearResponseLimit:         "LinearResponseLimit",
+	CameraSerialNumber:          "CameraSerialNumber",
+	DNGLensInfo:                 "DNGLensInfo",
+	ChromaBlurRadius:            "ChromaBlurRadius",
+	AntiAliasStrength:           "AntiAliasStrength",
+	ShadowScale:                 "ShadowScale",
+	DNGPrivateData:              "DNGPrivateData",
+	MakerNoteSafety:             "MakerNoteSafety",
+	CalibrationIlluminant1:      "CalibrationIlluminant1",
+	CalibrationIlluminant2:      "CalibrationIlluminant2",
+	BestQualityScale:            "BestQualityScale",
+	RawDataUniqueID:             "RawDataUniqueID",
+	OriginalRawFileName:         "OriginalRawFileName",
+	OriginalRawFileData:         "OriginalRawFileData",
+	ActiveArea:                  "ActiveArea",
+	MaskedAreas:                 "MaskedAreas",
+	AsShotICCProfile:            "AsShotICCProfile",
+	AsShotPreProfileMatrix:      "AsShotPreProfileMatrix",
+	CurrentICCProfile:           "CurrentICCProfile",
+	CurrentPreProfileMatrix:     "CurrentPreProfileMatrix",
+	ColorimetricReference:       "ColorimetricReference",
+	CameraCalibrationSig:        "CameraCalibrationSig",
+	ProfileCalibrationSig:       "ProfileCalibrationSig",
+	ProfileIFD:                  "ProfileIFD",
+	AsShotProfileName:           "AsShotProfileName",
+	NoiseReductionApplied:       "NoiseReductionApplied",
+	ProfileName:                 "ProfileName",
+	ProfileHueSatMapDims:        "ProfileHueSatMapDims",
+	ProfileHueSatMapData1:       "ProfileHueSatMapData1",
+	ProfileHueSatMapData2:       "ProfileHueSatMapData2",
+	ProfileToneCurve:            "ProfileToneCurve",
+	ProfileEmbedPolicy:          "ProfileEmbedPolicy",
+	ProfileCopyright:            "ProfileCopyright",
+	ForwardMatrix1:              "ForwardMatrix1",
+	ForwardMatrix2:              "ForwardMatrix2",
+	PreviewApplicationName:      "PreviewApplicationName",
+	PreviewApplicationVersion:   "PreviewApplicationVersion",
+	PreviewSettingsName:         "PreviewSettingsName",
+	PreviewSettingsDigest:       "PreviewSettingsDigest",
+	PreviewColorSpace:           "PreviewColorSpace",
+	PreviewDateTime:             "PreviewDateTime",
+	RawImageDigest:              "RawImageDigest",
+	OriginalRawFileDigest:       "OriginalRawFileDigest",
+	SubTileBlockSize:            "SubTileBlockSize",
+	RowInterleaveFactor:         "RowInterleaveFactor",
+	ProfileLookTableDims:        "ProfileLookTableDims",
+	ProfileLookTableData:        "ProfileLookTableData",
+	OpcodeList1:                 "OpcodeList1",
+	OpcodeList2:                 "OpcodeList2",
+	OpcodeList3:                 "OpcodeList3",
+	NoiseProfile:                "NoiseProfile",
+	Padding:                     "Padding",
+	ExposureTime:                "ExposureTime",
+	FNumber:                     "FNumber",
+	ExposureProgram:             "ExposureProgram",
+	SpectralSensitivity:         "SpectralSensitivity",
+	ISO:                         "ISO",
+	OECF:                        "OECF",
+	SensitivityType:             "SensitivityType",
+	StandardOutputSensitivity:   "StandardOutputSensitivity",
+	RecommendedExposureIndex:    "RecommendedExposureIndex",
+	ISOSpeed:                    "ISOSpeed",
+	ISOSpeedLatitudeyyy:         "ISOSpeedLatitudeyyy",
+	ISOSpeedLatitudezzz:         "ISOSpeedLatitudezzz",
+	ExifVersion:                 "ExifVersion",
+	DateTimeOriginal:            "DateTimeOriginal",
+	CreateDate:                  "CreateDate",
+	OffsetTime:                  "OffsetTime",
+	OffsetTimeOriginal:          "OffsetTimeOriginal",
+	OffsetTimeDigitized:         "OffsetTimeDigitized",
+	ComponentsConfiguration:     "ComponentsConfiguration",
+	CompressedBitsPerPixel:      "CompressedBitsPerPixel",
+	ShutterSpeedValue:           "ShutterSpeedValue",
+	ApertureValue:               "ApertureValue",
+	BrightnessValue:             "BrightnessValue",
+	ExposureCompensation:        "ExposureCompensation",
+	MaxApertureValue:            "MaxApertureValue",
+	SubjectDistance:             "SubjectDistance",
+	MeteringMode:                "MeteringMode",
+	LightSource:                 "LightSource",
+	Flash:                       "Flash",
+	FocalLength:                 "FocalLength",
+	SubjectArea:                 "SubjectArea",
+	MakerNote:                   "MakerNote",
+	UserComment:                 "UserComment",
+	SubSecTime:                  "SubSecTime",
+	SubSecTimeOriginal:          "SubSecTimeOriginal",
+	SubSecTimeDigitized:         "SubSecTimeDigitized",
+	AmbientTemperature:          "AmbientTemperature",
+	Humidity:                    "Humidity",
+	Pressure:                    "Pressure",
+	WaterDepth:                  "WaterDepth",
+	Acceleration:                "Acceleration",
+	CameraElevationAngle:        "CameraElevationAngle",
+	FlashpixVersion:             "FlashpixVersion",
+	ColorSpace:                  "ColorSpace",
+	ExifImageWidth:              "ExifImageWidth",
+	ExifImageHeight:             "ExifImageHeight",
+	RelatedSoundFile:            "RelatedSoundFile",
+	InteropOffset:               "InteropOffset",
+	FlashEnergy:                 "FlashEnergy",
+	SpatialFrequencyResponse:    "SpatialFrequencyResponse",
+	FocalPlaneXResolution:       "FocalPlaneXResolution",
+	FocalPlaneYResolution:       "FocalPlaneYResolution",
+	FocalPlaneResolutionUnit:    "FocalPlaneResolutionUnit",
+	SubjectLocation:             "SubjectLocation",
+	ExposureIndex:               "ExposureIndex",
+	SensingMethod:               "SensingMethod",
+	FileSource:                  "FileSource",
+	SceneType:                   "SceneType",
+	CFAPattern:                  "CFAPattern",
+	CustomRendered:              "CustomRendered",
+	ExposureMode:                "ExposureMode",
+	WhiteBalance:                "WhiteBalance",
+	DigitalZoomRatio:            "DigitalZoomRatio",
+	FocalLengthIn35mmFormat:     "FocalLengthIn35mmFormat",
+	SceneCaptureType:            "SceneCaptureType",
+	GainControl:                 "GainControl",
+	Contrast:                    "Contrast",
+	Saturation:                  "Saturation",
+	Sharpness:                   "Sharpness",
+	DeviceSettingDescription:    "DeviceSettingDescription",
+	SubjectDistanceRange:        "SubjectDistanceRange",
+	ImageUniqueID:               "ImageUniqueID",
+	OwnerName:                   "OwnerName",
+	SerialNumber:                "SerialNumber",
+	LensInfo:                    "LensInfo",
+	LensMake:                    "LensMake",
+	LensModel:                   "LensModel",
+	LensSerialNumber:            "LensSerialNumber",
+	ImageTitle:                  "ImageTitle",
+	Photographer:                "Photographer",
+	ImageEditor:                 "ImageEditor",
+	CameraFirmware:              "CameraFirmware",
+	RAWDevelopingSoftware:       "RAWDevelopingSoftware",
+	ImageEditingSoftware:        "ImageEditingSoftware",
+	MetadataEditingSoftware:     "MetadataEditingSoftware",
+	CompositeImage:              "CompositeImage",
+	CompositeImageCount:         "CompositeImageCount",
+	CompositeImageExposureTimes: "CompositeImageExposureTimes",
+	Gamma:                       "Gamma",
+	OffsetSchema:                "OffsetSchema",
+}
+
+func (e Exif) String() string {
+	if s, ok := eNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("UnknownTag(0x%04X)", uint16(e))
+}
+
+const (
+	GPSVersionID         GPS = 0x0000
+	GPSLatitudeRef       GPS = 0x0001
+	GPSLatitude          GPS = 0x0002
+	GPSLongitudeRef      GPS = 0x0003
+	GPSLongitude         GPS = 0x0004
+	GPSAltitudeRef       GPS = 0x0005
+	GPSAltitude          GPS = 0x0006
+	GPSTimeStamp         GPS = 0x0007
+	GPSSatellites        GPS = 0x0008
+	GPSStatus            GPS = 0x0009
+	GPSMeasureMode       GPS = 0x000A
+	GPSDOP               GPS = 0x000B
+	GPSSpeedRef          GPS = 0x000C
+	GPSSpeed             GPS = 0x000D
+	GPSTrackRef          GPS = 0x000E
+	GPSTrack             GPS = 0x000F
+	GPSImgDirectionRef   GPS = 0x0010
+	GPSImgDirection      GPS = 0x0011
+	GPSMapDatum          GPS = 0x0012
+	GPSDestLatitudeRef   GPS = 0x0013
+	GPSDestLatitude      GPS = 0x0014
+	GPSDestLongitudeRef  GPS = 0x0015
+	GPSDestLongitude     GPS = 0x0016
+	GPSDestBearingRef    GPS = 0x0017
+	GPSDestBearing       GPS = 0x0018
+	GPSDestDistanceRef   GPS = 0x0019
+	GPSDestDistance      GPS = 0x001A
+	GPSProcessingMethod  GPS = 0x001B
+	GPSAreaInformation   GPS = 0x001C
+	GPSDateStamp         GPS = 0x001D
+	GPSDifferential      GPS = 0x001E
+	GPSHPositioningError GPS = 0x001F
+)
+
+var gNames = map[GPS]string{
+	GPSVersionID:         "GPSVersionID",
+	GPSLatitudeRef:       "GPSLatitudeRef",
+	GPSLatitude:          "GPSLatitude",
+	GPSLongitudeRef:      "GPSLongitudeRef",
+	GPSLongitude:         "GPSLongitude",
+	GPSAltitudeRef:       "GPSAltitudeRef",
+	GPSAltitude:          "GPSAltitude",
+	GPSTimeStamp:         "GPSTimeStamp",
+	GPSSatellites:        "GPSSatellites",
+	GPSStatus:            "GPSStatus",
+	GPSMeasureMode:       "GPSMeasureMode",
+	GPSDOP:               "GPSDOP",
+	GPSSpeedRef:          "GPSSpeedRef",
+	GPSSpeed:             "GPSSpeed",
+	GPSTrackRef:          "GPSTrackRef",
+	GPSTrack:             "GPSTrack",
+	GPSImgDirectionRef:   "GPSImgDirectionRef",
+	GPSImgDirection:      "GPSImgDirection",
+	GPSMapDatum:          "GPSMapDatum",
+	GPSDestLatitudeRef:   "GPSDestLatitudeRef",
+	GPSDestLatitude:      "GPSDestLatitude",
+	GPSDestLongitudeRef:  "GPSDestLongitudeRef",
+	GPSDestLongitude:     "GPSDestLongitude",
+	GPSDestBearingRef:    "GPSDestBearingRef",
+	GPSDestBearing:       "GPSDestBearing",
+	GPSDestDistanceRef:   "GPSDestDistanceRef",
+	GPSDestDistance:      "GPSDestDistance",
+	GPSProcessingMethod:  "GPSProcessingMethod",
+	GPSAreaInformation:   "GPSAreaInformation",
+	GPSDateStamp:         "GPSDateStamp",
+	GPSDifferential:      "GPSDifferential",
+	GPSHPositioningError: "GPSHPositioningError",
+}
+
+func (g GPS) String() string {
+	if s, ok := gNames[g]; ok {
+		return s
+	}
+	return fmt.Sprintf("UnknownTag(0x%04X)", uint16(g))
+}
+
+const (
+	InteropIndex           Interop = 0x0001
+	InteropVersion         Interop = 0x0002
+	RelatedImageFileFormat Interop = 0x1000
+	RelatedImageWidth      Interop = 0x1001
+	RelatedImageHeight     Interop = 0x1002
+)
+
+var iNames = map[Interop]string{
+	InteropIndex:           "InteropIndex",
+	InteropVersion:         "InteropVersion",
+	RelatedImageFileFormat: "RelatedImageFileFormat",
+	RelatedImageWidth:      "RelatedImageWidth",
+	RelatedImageHeight:     "RelatedImageHeight",
+}
+
+func (i Interop) String() string {
+	if s, ok := iNames[i]; ok {
+		return s
+	}
+	return fmt.Sprintf("UnknownTag(0x%04X)", uint16(i))
+}
+
+var infos = []Info{
+	{IFDTIFF, 0x000B, "ProcessingSoftware", []Type{ASCII}, 0, "name of the software that processed the image"},
+	{IFDTIFF, 0x00FE, "SubfileType", []Type{LONG}, 1, "type of data contained in this IFD"},
+	{IFDTIFF, 0x00FF, "OldSubfileType", []Type{SHORT}, 1, "type of data contained in this IFD (superseded by SubfileType)"},
+	{IFDTIFF, 0x0100, "ImageWidth", []Type{SHORT, LONG}, 1, "number of columns of image data"},
+	{IFDTIFF, 0x0101, "ImageHeight", []Type{SHORT, LONG}, 1, "number of rows of image data"},
+	{IFDTIFF, 0x0102, "BitsPerSample", []Type{SHORT}, 0, "number of bits per component"},
+	{IFDTIFF, 0x0103, "Compression", []Type{SHORT}, 1, "compression scheme of the image data"},
+	{IFDTIFF, 0x0106, "PhotometricInterpretation", []Type{SHORT}, 1, "pixel composition"},
+	{IFDTIFF, 0x0107, "Thresholding", []Type{SHORT}, 1, "technique used to convert from gray to black and white pixels"},
+	{IFDTIFF, 0x0108, "CellWidth", []Type{SHORT}, 1, "width of the dithering or halftoning matrix"},
+	{IFDTIFF, 0x0109, "CellLength", []Type{SHORT}, 1, "length of the dithering or halftoning matrix"},
+	{IFDTIFF, 0x010A, "FillOrder", []Type{SHORT}, 1, "logical order of bits within a byte"},
+	{IFDTIFF, 0x010D, "DocumentName", []Type{ASCII}, 0, "name of the document from which the image was scanned"},
+	{IFDTIFF, 0x010E, "ImageDescription", []Type{ASCII}, 0, "title of the image"},
+	{IFDTIFF, 0x010F, "Make", []Type{ASCII}, 0, "manufacturer of the recording equipment"},
+	{IFDTIFF, 0x0110, "Model", []Type{ASCII}, 0, "model name or number of the equipment"},
+	{IFDTIFF, 0x0111, "StripOffsets", []Type{SHORT, LONG}, 0, "byte offset of each strip"},
+	{IFDTIFF, 0x0112, "Orientation", []Type{SHORT}, 1, "orientation of the image relative to rows and columns"},
+	{IFDTIFF, 0x0115, "SamplesPerPixel", []Type{SHORT}, 1, "number of components per pixel"},
+	{IFDTIFF, 0x0116, "RowsPerStrip", []Type{SHORT, LONG}, 1, "number of rows per strip"},
+	{IFDTIFF, 0x0117, "StripByteCounts", []Type{SHORT, LONG}, 0, "number of bytes in each strip after compression"},
+	{IFDTIFF, 0x0118, "MinSampleValue", []Type{SHORT}, 0, "minimum component value used"},
+	{IFDTIFF, 0x0119, "MaxSampleValue", []Type{SHORT}, 0, "maximum component value used"},
+	{IFDTIFF, 0x011A, "XResolution", []Type{RATIONAL}, 1, "pixels per ResolutionUnit in the image width direction"},
+	{IFDTIFF, 0x011B, "YResolution", []Type{RATIONAL}, 1, "pixels per ResolutionUnit in the image height direction"},
+	{IFDTIFF, 0x011C, "PlanarConfiguration", []Type{SHORT}, 1, "how the components of each pixel are stored"},
+	{IFDTIFF, 0x011D, "PageName", []Type{ASCII}, 0, "name of the page from which the image was scanned"},
+	{IFDTIFF, 0x011E, "XPosition", []Type{RATIONAL}, 1, "X position of the image"},
+	{IFDTIFF, 0x011F, "YPosition", []Type{RATIONAL}, 1, "Y position of the image"},
+	{IFDTIFF, 0x0120, "FreeOffsets", []Type{LONG}, 0, "byte offsets of unused strings"},
+	{IFDTIFF, 0x0121, "FreeByteCounts", []Type{LONG}, 0, "byte counts of unused strings"},
+	{IFDTIFF, 0x0122, "GrayResponseUnit", []Type{SHORT}, 1, "precision of the GrayResponseCurve values"},
+	{IFDTIFF, 0x0123, "GrayResponseCurve", []Type{SHORT}, 0, "optical density of each possible pixel value"},
+	{IFDTIFF, 0x0124, "T4Options", []Type{LONG}, 1, "options for CCITT Group 3 compression"},
+	{IFDTIFF, 0x0125, "T6Options", []Type{LONG}, 1, "options for CCITT Group 4 compression"},
+	{IFDTIFF, 0x0128, "ResolutionUnit", []Type{SHORT}, 1, "unit of XResolution and YResolution"},
+	{IFDTIFF, 0x0129, "PageNumber", []Type{SHORT}, 2, "page number of the page from which the image was scanned"},
+	{IFDTIFF, 0x012D, "TransferFunction", []Type{SHORT}, 0, "transfer function of the image"},
+	{IFDTIFF, 0x0131, "Software", []Type{ASCII}, 0, "software used to generate the image"},
+	{IFDTIFF, 0x0132, "ModifyDate", []Type{ASCII}, 20, "date and time the file was changed"},
+	{IFDTIFF, 0x013B, "Artist", []Type{ASCII}, 0, "person who created the image"},
+	{IFDTIFF, 0x013C, "HostComputer", []Type{ASCII}, 0, "computer used to create the image"},
+	{IFDTIFF, 0x013D, "Predictor", []Type{SHORT}, 1, "predictor applied before the coding scheme"},
+	{IFDTIFF, 0x013E, "WhitePoint", []Type{RATIONAL}, 2, "chromaticity of the white point"},
+	{IFDTIFF, 0x013F, "PrimaryChromaticities", []Type{RATIONAL}, 6, "chromaticities of the primaries"},
+	{IFDTIFF, 0x0140, "ColorMap", []Type{SHORT}, 0, "color map for palette color images"},
+	{IFDTIFF, 0x0141, "HalftoneHints", []Type{SHORT}, 2, "range of gray levels retaining tonal detail"},
+	{IFDTIFF, 0x0142, "TileWidth", []Type{SHORT, LONG}, 1, "number of columns in each tile"},
+	{IFDTIFF, 0x0143, "TileLength", []Type{SHORT, LONG}, 1, "number of rows in each tile"},
+	{IFDTIFF, 0x0144, "TileOffsets", []Type{LONG}, 0, "byte offset of each tile"},
+	{IFDTIFF, 0x0145, "TileByteCounts", []Type{SHORT, LONG}, 0, "number of bytes in each compressed tile"},
+	{IFDTIFF, 0x014A, "SubIFD", []Type{LONG}, 0, "offsets to child IFDs"},
+	{IFDTIFF, 0x014C, "InkSet", []Type{SHORT}, 1, "set of inks used in a separated image"},
+	{IFDTIFF, 0x014D, "InkNames", []Type{ASCII}, 0, "names of the inks used in a separated image"},
+	{IFDTIFF, 0x014E, "NumberofInks", []Type{SHORT}, 1, "number of inks"},
+	{IFDTIFF, 0x0150, "DotRange", []Type{BYTE, SHORT}, 0, "component values corresponding to 0% and 100% dots"},
+	{IFDTIFF, 0x0151, "TargetPrinter", []Type{ASCII}, 0, "intended printing environment"},
+	{IFDTIFF, 0x0152, "ExtraSamples", []Type{SHORT}, 0, "description of extra components"},
+	{IFDTIFF, 0x0153, "SampleFormat", []Type{SHORT}, 0, "how to interpret each data sample"},
+	{IFDTIFF, 0x0154, "SMinSampleValue", []Type{SHORT, LONG, RATIONAL, DOUBLE}, 0, "minimum sample value"},
+	{IFDTIFF, 0x0155, "SMaxSampleValue", []Type{SHORT, LONG, RATIONAL, DOUBLE}, 0, "maximum sample value"},
+	{IFDTIFF, 0x0156, "TransferRange", []Type{SHORT}, 6, "expands the range of the TransferFunction"},
+	{IFDTIFF, 0x0157, "ClipPath", []Type{BYTE}, 0, "clipping path outlining the image"},
+	{IFDTIFF, 0x0158, "XClipPathUnits", []Type{LONG}, 1, "units of the horizontal clipping path coordinates"},
+	{IFDTIFF, 0x0159, "YClipPathUnits", []Type{LONG}, 1, "units of the vertical clipping path coordinates"},
+	{IFDTIFF, 0x015A, "Indexed", []Type{SHORT}, 1, "whether the image uses a color map"},
+	{IFDTIFF, 0x015B, "JPEGTables", []Type{UNDEFINED}, 0, "shared JPEG quantization and Huffman tables"},
+	{IFDTIFF, 0x015F, "OPIProxy", []Type{SHORT}, 1, "whether a high resolution version of the image exists"},
+	{IFDTIFF, 0x0200, "JPEGProc", []Type{SHORT}, 1, "JPEG process used to produce the compressed data"},
+	{IFDTIFF, 0x0201, "ThumbnailOffset", []Type{LONG}, 1, "offset to the JPEG thumbnail SOI"},
+	{IFDTIFF, 0x0202, "ThumbnailLength", []Type{LONG}, 1, "number of bytes of the JPEG thumbnail"},
+	{IFDTIFF, 0x0203, "JPEGRestartInterval", []Type{SHORT}, 1, "length of the JPEG restart interval"},
+	{IFDTIFF, 0x0205, "JPEGLosslessPredictors", []Type{SHORT}, 0, "lossless predictor selection values"},
+	{IFDTIFF, 0x0206, "JPEGPointTransforms", []Type{SHORT}, 0, "point transform values"},
+	{IFDTIFF, 0x0207, "JPEGQTables", []Type{LONG}, 0, "offsets to the quantization tables"},
+	{IFDTIFF, 0x0208, "JPEGDCTables", []Type{LONG}, 0, "offsets to the DC Huffman tables"},
+	{IFDTIFF, 0x0209, "JPEGACTables", []Type{LONG}, 0, "offsets to the AC Huffman tables"},
+	{IFDTIFF, 0x0211, "YCbCrCoefficients", []Type{RATIONAL}, 3, "matrix coefficients for RGB to YCbCr transformation"},
+	{IFDTIFF, 0x0212, "YCbCrSubSampling", []Type{SHORT}, 2, "sampling ratio of chrominance components"},
+	{IFDTIFF, 0x0213, "YCbCrPositioning", []Type{SHORT}, 1, "position of chrominance components relative to luminance"},
+	{IFDTIFF, 0x0214, "ReferenceBlackWhite", []Type{RATIONAL}, 6, "reference black and white point values"},
+	{IFDTIFF, 0x02BC, "ApplicationNotes", []Type{BYTE}, 0, "XMP packet"},
+	{IFDTIFF, 0x4746, "Rating", []Type{SHORT}, 1, "rating from 0 to 5 stars"},
+	{IFDTIFF, 0x4749, "RatingPercent", []Type{SHORT}, 1, "rating in percent"},
+	{IFDTIFF, 0x800D, "ImageID", []Type{ASCII}, 0, "OPI image identifier"},
+	{IFDTIFF, 0x828D, "CFARepeatPatternDim", []Type{SHORT}, 2, "dimensions of the CFA repeat pattern"},
+	{IFDTIFF, 0x828E, "CFAPattern2", []Type{BYTE}, 0, "color filter array geometric pattern"},
+	{IFDTIFF, 0x828F, "BatteryLevel", []Type{RATIONAL, ASCII}, 0, "battery level at capture time"},
+	{IFDTIFF, 0x8298, "Copyright", []Type{ASCII}, 0, "copyright holder"},
+	{IFDTIFF, 0x83BB, "IPTCNAA", []Type{LONG, UNDEFINED}, 0, "IPTC-NAA record"},
+	{IFDTIFF, 0x8649, "PhotoshopSettings", []Type{BYTE}, 0, "Photoshop image resource blocks"},
+	{IFDTIFF, 0x8769, "ExifOffset", []Type{LONG}, 1, "pointer to the Exif SubIFD"},
+	{IFDTIFF, 0x8773, "ICC_Profile", []Type{UNDEFINED}, 0, "embedded ICC color profile"},
+	{IFDTIFF, 0x8825, "GPSInfo", []Type{LONG}, 1, "pointer to the GPS IFD"},
+	{IFDTIFF, 0x882A, "TimeZoneOffset", []Type{SSHORT}, 0, "time zone offset in hours"},
+	{IFDTIFF, 0x882B, "SelfTimerMode", []Type{SHORT}, 1, "self timer delay in seconds"},
+	{IFDTIFF, 0x9211, "ImageNumber", []Type{LONG}, 1, "image number"},
+	{IFDTIFF, 0x9212, "SecurityClassification", []Type{ASCII}, 0, "security classification"},
+	{IFDTIFF, 0x9213, "ImageHistory", []Type{ASCII}, 0, "record of what has been done to the image"},
+	{IFDTIFF, 0x9216, "TIFF_EPStandardID", []Type{BYTE}, 4, "TIFF/EP standard version"},
+	{IFDTIFF, 0x935C, "ImageSourceData", []Type{UNDEFINED}, 0, "Photoshop layer data"},
+	{IFDTIFF, 0x9C9B, "XPTitle", []Type{BYTE}, 0, "title as UCS-2 string"},
+	{IFDTIFF, 0x9C9C, "XPComment", []Type{BYTE}, 0, "comment as UCS-2 string"},
+	{IFDTIFF, 0x9C9D, "XPAuthor", []Type{BYTE}, 0, "author as UCS-2 string"},
+	{IFDTIFF, 0x9C9E, "XPKeywords", []Type{BYTE}, 0, "keywords as UCS-2 string"},
+	{IFDTIFF, 0x9C9F, "XPSubject", []Type{BYTE}, 0, "subject as UCS-2 string"},
+	{IFDTIFF, 0xA480, "GDALMetadata", []Type{ASCII}, 0, "GDAL metadata"},
+	{IFDTIFF, 0xA481, "GDALNoData", []Type{ASCII}, 0, "GDAL no-data value"},
+	{IFDTIFF, 0xC4A5, "PrintIM", []Type{UNDEFINED}, 0, "Epson print image matching data"},
+	{IFDTIFF, 0xC612, "DNGVersion", []Type{BYTE}, 4, "DNG specification version"},
+	{IFDTIFF, 0xC613, "DNGBackwardVersion", []Type{BYTE}, 4, "oldest DNG version readers must support"},
+	{IFDTIFF, 0xC614, "UniqueCameraModel", []Type{ASCII}, 0, "unique non-localized camera model name"},
+	{IFDTIFF, 0xC615, "LocalizedCameraModel", []Type{ASCII, BYTE}, 0, "localized camera model name"},
+	{IFDTIFF, 0xC616, "CFAPlaneColor", []Type{BYTE}, 0, "mapping of CFA plane colors"},
+	{IFDTIFF, 0xC617, "CFALayout", []Type{SHORT}, 1, "spatial layout of the CFA"},
+	{IFDTIFF, 0xC618, "LinearizationTable", []Type{SHORT}, 0, "lookup table mapping stored values to linear values"},
+	{IFDTIFF, 0xC619, "BlackLevelRepeatDim", []Type{SHORT}, 2, "repeat pattern size of BlackLevel"},
+	{IFDTIFF, 0xC61A, "BlackLevel", []Type{SHORT, LONG, RATIONAL}, 0, "zero light encoding level"},
+	{IFDTIFF, 0xC61B, "BlackLevelDeltaH", []Type{SRATIONAL}, 0, "per-column black level offsets"},
+	{IFDTIFF, 0xC61C, "BlackLevelDeltaV", []Type{SRATIONAL}, 0, "per-row black level offsets"},
+	{IFDTIFF, 0xC61D, "WhiteLevel", []Type{SHORT, LONG}, 0, "fully saturated encoding level"},
+	{IFDTIFF, 0xC61E, "DefaultScale", []Type{RATIONAL}, 2, "default scale factors"},
+	{IFDTIFF, 0xC61F, "DefaultCropOrigin", []Type{SHORT, LONG, RATIONAL}, 2, "origin of the final image area"},
+	{IFDTIFF, 0xC620, "DefaultCropSize", []Type{SHORT, LONG, RATIONAL}, 2, "size of the final image area"},
+	{IFDTIFF, 0xC621, "ColorMatrix1", []Type{SRATIONAL}, 0, "XYZ to camera color space matrix for illuminant 1"},
+	{IFDTIFF, 0xC622, "ColorMatrix2", []Type{SRATIONAL}, 0, "XYZ to camera color space matrix for illuminant 2"},
+	{IFDTIFF, 0xC623, "CameraCalibration1", []Type{SRATIONAL}, 0, "calibration matrix for illuminant 1"},
+	{IFDTIFF, 0xC624, "CameraCalibration2", []Type{SRATIONAL}, 0, "calibration matrix for illuminant 2"},
+	{IFDTIFF, 0xC625, "ReductionMatrix1", []Type{SRATIONAL}, 0, "dimensionality reduction matrix for illuminant 1"},
+	{IFDTIFF, 0xC626, "ReductionMatrix2", []Type{SRATIONAL}, 0, "dimensionality reduction matrix for illuminant 2"},
+	{IFDTIFF, 0xC627, "AnalogBalance", []Type{RATIONAL}, 0, "gain applied to the stored raw values"},
+	{IFDTIFF, 0xC628, "AsShotNeutral", []Type{SHORT, RATIONAL}, 0, "selected white balance as camera neutral coordinates"},
+	{IFDTIFF, 0xC629, "AsShotWhiteXY", []Type{RATIONAL}, 2, "selected white balance as x-y chromaticity coordinates"},
+	{IFDTIFF, 0xC62A, "BaselineExposure", []Type{SRATIONAL}, 1, "baseline exposure compensation in EV"},
+	{IFDTIFF, 0xC62B, "BaselineNoise", []Type{RATIONAL}, 1, "relative noise level of the camera model"},
+	{IFDTIFF, 0xC62C, "BaselineSharpness", []Type{RATIONAL}, 1, "relative sharpening of the camera model"},
+	{IFDTIFF, 0xC62D, "BayerGreenSplit", []Type{LONG}, 1, "green split between green pixels of a Bayer CFA"},
+	{IFDTIFF, 0xC62E, "LinearResponseLimit", []Type{RATIONAL}, 1, "fraction of the encoding range above which the response is non-linear"},
+	{IFDTIFF, 0xC62F, "CameraSerialNumber", []Type{ASCII}, 0, "serial number of the camera"},
+	{IFDTIFF, 0xC630, "DNGLensInfo", []Type{RATIONAL}, 4, "min/max focal length and min/max F number of the lens"},
+	{IFDTIFF, 0xC631, "ChromaBlurRadius", []Type{RATIONAL}, 1, "chroma blur radius applied to the raw data"},
+	{IFDTIFF, 0xC632, "AntiAliasStrength", []Type{RATIONAL}, 1, "strength of the camera anti-alias filter"},
+	{IFDTIFF, 0xC633, "ShadowScale", []Type{RATIONAL}, 1, "used by Adobe Camera Raw to control shadow sensitivity"},
+	{IFDTIFF, 0xC634, "DNGPrivateData", []Type{BYTE}, 0, "private DNG data"},
+	{IFDTIFF, 0xC635, "MakerNoteSafety", []Type{SHORT}, 1, "whether the MakerNote is safe to preserve"},
+	{IFDTIFF, 0xC65A, "CalibrationIlluminant1", []Type{SHORT}, 1, "illuminant of the first set of color calibration tags"},
+	{IFDTIFF, 0xC65B, "CalibrationIlluminant2", []Type{SHORT}, 1, "illuminant of the second set of color calibration tags"},
+	{IFDTIFF, 0xC65C, "BestQualityScale", []Type{RATIONAL}, 1, "scale factor for the best quality image"},
+	{IFDTIFF, 0xC65D, "RawDataUniqueID", []Type{BYTE}, 16, "unique identifier of the raw image data"},
+	{IFDTIFF, 0xC68B, "OriginalRawFileName", []Type{ASCII, BYTE}, 0, "file name of the original raw file"},
+	{IFDTIFF, 0xC68C, "OriginalRawFileData", []Type{UNDEFINED}, 0, "contents of the original raw file"},
+	{IFDTIFF, 0xC68D, "ActiveArea", []Type{SHORT, LONG}, 4, "rectangle of the sensor containing valid image data"},
+	{IFDTIFF, 0xC68E, "MaskedAreas", []Type{SHORT, LONG}, 0, "fully masked rectangles of the sensor"},
+	{IFDTIFF, 0xC68F, "AsShotICCProfile", []Type{UNDEFINED}, 0, "ICC profile for the as shot rendering"},
+	{IFDTIFF, 0xC690, "AsShotPreProfileMatrix", []Type{SRATIONAL}, 0, "matrix applied before AsShotICCProfile"},
+	{IFDTIFF, 0xC691, "CurrentICCProfile", []Type{UNDEFINED}, 0, "ICC profile for the current rendering"},
+	{IFDTIFF, 0xC692, "CurrentPreProfileMatrix", []Type{SRATIONAL}, 0, "matrix applied before CurrentICCProfile"},
+	{IFDTIFF, 0xC6BF, "ColorimetricReference", []Type{SHORT}, 1, "colorimetric reference of the output"},
+	{IFDTIFF, 0xC6F3, "CameraCalibrationSig", []Type{ASCII, BYTE}, 0, "signature of the camera calibration"},
+	{IFDTIFF, 0xC6F4, "ProfileCalibrationSig", []Type{ASCII, BYTE}, 0, "signature of the profile calibration"},
+	{IFDTIFF, 0xC6F5, "ProfileIFD", []Type{LONG}, 0, "offsets to extra camera profiles"},
+	{IFDTIFF, 0xC6F6, "AsShotProfileName", []Type{ASCII, BYTE}, 0, "name of the as shot camera profile"},
+	{IFDTIFF, 0xC6F7, "NoiseReductionApplied", []Type{RATIONAL}, 1, "amount of noise reduction applied to the raw data"},
+	{IFDTIFF, 0xC6F8, "ProfileName", []Type{ASCII, BYTE}, 0, "name of the camera profile"},
+	{IFDTIFF, 0xC6F9, "ProfileHueSatMapDims", []Type{LONG}, 3, "dimensions of the hue/saturation/value tables"},
+	{IFDTIFF, 0xC6FA, "ProfileHueSatMapData1", []Type{FLOAT}, 0, "hue/saturation/value table for illuminant 1"},
+	{IFDTIFF, 0xC6FB, "ProfileHueSatMapData2", []Type{FLOAT}, 0, "hue/saturation/value table for illuminant 2"},
+	{IFDTIFF, 0xC6FC, "ProfileToneCurve", []Type{FLOAT}, 0, "default tone curve of the profile"},
+	{IFDTIFF, 0xC6FD, "ProfileEmbedPolicy", []Type{LONG}, 1, "usage rules for the camera profile"},
+	{IFDTIFF, 0xC6FE, "ProfileCopyright", []Type{ASCII, BYTE}, 0, "copyright of the camera profile"},
+	{IFDTIFF, 0xC714, "ForwardMatrix1", []Type{SRATIONAL}, 0, "white balanced camera to XYZ D50 matrix for illuminant 1"},
+	{IFDTIFF, 0xC715, "ForwardMatrix2", []Type{SRATIONAL}, 0, "white balanced camera to XYZ D50 matrix for illuminant 2"},
+	{IFDTIFF, 0xC716, "PreviewApplicationName", []Type{ASCII, BYTE}, 0, "name of the application that created the preview"},
+	{IFDTIFF, 0xC717, "PreviewApplicationVersion", []Type{ASCII, BYTE}, 0, "version of the application that created the preview"},
+	{IFDTIFF, 0xC718, "PreviewSettingsName", []Type{ASCII, BYTE}, 0, "name of the conversion settings used for the preview"},
+	{IFDTIFF, 0xC719, "PreviewSettingsDigest", []Type{BYTE}, 16, "digest of the conversion settings used for the preview"},
+	{IFDTIFF, 0xC71A, "PreviewColorSpace", []Type{LONG}, 1, "color space of the preview"},
+	{IFDTIFF, 0xC71B, "PreviewDateTime", []Type{ASCII}, 0, "date and time the preview was rendered"},
+	{IFDTIFF, 0xC71C, "RawImageDigest", []Type{BYTE}, 16, "MD5 digest of the raw image data"},
+	{IFDTIFF, 0xC71D, "OriginalRawFileDigest", []Type{BYTE}, 16, "MD5 digest of OriginalRawFileData"},
+	{IFDTIFF, 0xC71E, "SubTileBlockSize", []Type{SHORT, LONG}, 2, "size of the sub-tile blocks"},
+	{IFDTIFF, 0xC71F, "RowInterleaveFactor", []Type{SHORT, LONG}, 1, "number of interleaved fields"},
+	{IFDTIFF, 0xC725, "ProfileLookTableDims", []Type{LONG}, 3, "dimensions of the look table"},
+	{IFDTIFF, 0xC726, "ProfileLookTableData", []Type{FLOAT}, 0, "look table of the profile"},
+	{IFDTIFF, 0xC740, "OpcodeList1", []Type{UNDEFINED}, 0, "opcodes applied to the raw image as read"},
+	{IFDTIFF, 0xC741, "OpcodeList2", []Type{UNDEFINED}, 0, "opcodes applied after linearization"},
+	{IFDTIFF, 0xC74E, "OpcodeList3", []Type{UNDEFINED}, 0, "opcodes applied after demosaicing"},
+	{IFDTIFF, 0xC761, "NoiseProfile", []Type{DOUBLE}, 0, "noise model of the raw image data"},
+	{IFDTIFF, 0xEA1C, "Padding", []Type{UNDEFINED}, 0, "Microsoft padding"},
+	{IFDExif, 0x829A, "ExposureTime", []Type{RATIONAL}, 1, "exposure time in seconds"},
+	{IFDExif, 0x829D, "FNumber", []Type{RATIONAL}, 1, "F number"},
+	{IFDExif, 0x8822, "ExposureProgram", []Type{SHORT}, 1, "class of program used to set exposure"},
+	{IFDExif, 0x8824, "SpectralSensitivity", []Type{ASCII}, 0, "spectral sensitivity of each channel"},
+	{IFDExif, 0x8827, "ISO", []Type{SHORT}, 0, "ISO speed rating"},
+	{IFDExif, 0x8828, "OECF", []Type{UNDEFINED}, 0, "opto-electric conversion function"},
+	{IFDExif, 0x8830, "SensitivityType", []Type{SHORT}, 1, "which of the ISO parameters is recorded"},
+	{IFDExif, 0x8831, "StandardOutputSensitivity", []Type{LONG}, 1, "standard output sensitivity (ISO 12232)"},
+	{IFDExif, 0x8832, "RecommendedExposureIndex", []Type{LONG}, 1, "recommended exposure index (ISO 12232)"},
+	{IFDExif, 0x8833, "ISOSpeed", []Type{LONG}, 1, "ISO speed (ISO 12232)"},
+	{IFDExif, 0x8834, "ISOSpeedLatitudeyyy", []Type{LONG}, 1, "ISO speed latitude yyy"},
+	{IFDExif, 0x8835, "ISOSpeedLatitudezzz", []Type{LONG}, 1, "ISO speed latitude zzz"},
+	{IFDExif, 0x9000, "ExifVersion", []Type{UNDEFINED}, 4, "version of the Exif standard"},
+	{IFDExif, 0x9003, "DateTimeOriginal", []Type{ASCII}, 20, "date and time the original image was generated"},
+	{IFDExif, 0x9004, "CreateDate", []Type{ASCII}, 20, "date and time the image was stored as digital data"},
+	{IFDExif, 0x9010, "OffsetTime", []Type{ASCII}, 7, "time zone offset of ModifyDate"},
+	{IFDExif, 0x9011, "OffsetTimeOriginal", []Type{ASCII}, 7, "time zone offset of DateTimeOriginal"},
+	{IFDExif, 0x9012, "OffsetTimeDigitized", []Type{ASCII}, 7, "time zone offset of CreateDate"},
+	{IFDExif, 0x9101, "ComponentsConfiguration", []Type{UNDEFINED}, 4, "meaning of each component"},
+	{IFDExif, 0x9102, "CompressedBitsPerPixel", []Type{RATIONAL}, 1, "compression mode used for the image"},
+	{IFDExif, 0x9201, "ShutterSpeedValue", []Type{SRATIONAL}, 1, "shutter speed in APEX units"},
+	{IFDExif, 0x9202, "ApertureValue", []Type{RATIONAL}, 1, "lens aperture in APEX units"},
+	{IFDExif, 0x9203, "BrightnessValue", []Type{SRATIONAL}, 1, "brightness in APEX units"},
+	{IFDExif, 0x9204, "ExposureCompensation", []Type{SRATIONAL}, 1, "exposure bias in APEX units"},
+	{IFDExif, 0x9205, "MaxApertureValue", []Type{RATIONAL}, 1, "smallest F number of the lens in APEX units"},
+	{IFDExif, 0x9206, "SubjectDistance", []Type{RATIONAL}, 1, "distance to the subject in meters"},
+	{IFDExif, 0x9207, "MeteringMode", []Type{SHORT}, 1, "metering mode"},
+	{IFDExif, 0x9208, "LightSource", []Type{SHORT}, 1, "kind of light source"},
+	{IFDExif, 0x9209, "Flash", []Type{SHORT}, 1, "status of flash when the image was shot"},
+	{IFDExif, 0x920A, "FocalLength", []Type{RATIONAL}, 1, "actual focal length of the lens in mm"},
+	{IFDExif, 0x9214, "SubjectArea", []Type{SHORT}, 0, "location and area of the main subject"},
+	{IFDExif, 0x927C, "MakerNote", []Type{UNDEFINED}, 0, "manufacturer specific information"},
+	{IFDExif, 0x9286, "UserComment", []Type{UNDEFINED}, 0, "keywords or comments on the image"},
+	{IFDExif, 0x9290, "SubSecTime", []Type{ASCII}, 0, "fractions of seconds of ModifyDate"},
+	{IFDExif, 0x9291, "SubSecTimeOriginal", []Type{ASCII}, 0, "fractions of seconds of DateTimeOriginal"},
+	{IFDExif, 0x9292, "SubSecTimeDigitized", []Type{ASCII}, 0, "fractions of seconds of CreateDate"},
+	{IFDExif, 0x9400, "AmbientTemperature", []Type{SRATIONAL}, 1, "ambient temperature in degrees Celsius"},
+	{IFDExif, 0x9401, "Humidity", []Type{RATIONAL}, 1, "ambient relative humidity in percent"},
+	{IFDExif, 0x9402, "Pressure", []Type{RATIONAL}, 1, "air or water pressure in hPa"},
+	{IFDExif, 0x9403, "WaterDepth", []Type{SRATIONAL}, 1, "water depth in meters"},
+	{IFDExif, 0x9404, "Acceleration", []Type{RATIONAL}, 1, "acceleration in mGal"},
+	{IFDExif, 0x9405, "CameraElevationAngle", []Type{SRATIONAL}, 1, "elevation angle of the camera in degrees"},
+	{IFDExif, 0xA000, "FlashpixVersion", []Type{UNDEFINED}, 4, "supported Flashpix format version"},
+	{IFDExif, 0xA001, "ColorSpace", []Type{SHORT}, 1, "color space information"},
+	{IFDExif, 0xA002, "ExifImageWidth", []Type{SHORT, LONG}, 1, "valid width of the meaningful image"},
+	{IFDExif, 0xA003, "ExifImageHeight", []Type{SHORT, LONG}, 1, "valid height of the meaningful image"},
+	{IFDExif, 0xA004, "RelatedSoundFile", []Type{ASCII}, 13, "name of an audio file related to the image"},
+	{IFDExif, 0xA005, "InteropOffset", []Type{LONG}, 1, "pointer to the Interoperability IFD"},
+	{IFDExif, 0xA20B, "FlashEnergy", []Type{RATIONAL}, 1, "strobe energy in BCPS"},
+	{IFDExif, 0xA20C, "SpatialFrequencyResponse", []Type{UNDEFINED}, 0, "spatial frequency table and SFR values"},
+	{IFDExif, 0xA20E, "FocalPlaneXResolution", []Type{RATIONAL}, 1, "pixels per FocalPlaneResolutionUnit in the image width direction"},
+	{IFDExif, 0xA20F, "FocalPlaneYResolution", []Type{RATIONAL}, 1, "pixels per FocalPlaneResolutionUnit in the image height direction"},
+	{IFDExif, 0xA210, "FocalPlaneResolutionUnit", []Type{SHORT}, 1, "unit of FocalPlaneXResolution and FocalPlaneYResolution"},
+	{IFDExif, 0xA214, "SubjectLocation", []Type{SHORT}, 2, "location of the main subject"},
+	{IFDExif, 0xA215, "ExposureIndex", []Type{RATIONAL}, 1, "selected exposure index"},
+	{IFDExif, 0xA217, "SensingMethod", []Type{SHORT}, 1, "image sensor type"},
+	{IFDExif, 0xA300, "FileSource", []Type{UNDEFINED}, 1, "image source"},
+	{IFDExif, 0xA301, "SceneType", []Type{UNDEFINED}, 1, "type of scene"},
+	{IFDExif, 0xA302, "CFAPattern", []Type{UNDEFINED}, 0, "color filter array geometric pattern"},
+	{IFDExif, 0xA401, "CustomRendered", []Type{SHORT}, 1, "use of special processing on image data"},
+	{IFDExif, 0xA402, "ExposureMode", []Type{SHORT}, 1, "exposure mode set when the image was shot"},
+	{IFDExif, 0xA403, "WhiteBalance", []Type{SHORT}, 1, "white balance mode set when the image was shot"},
+	{IFDExif, 0xA404, "DigitalZoomRatio", []Type{RATIONAL}, 1, "digital zoom ratio"},
+	{IFDExif, 0xA405, "FocalLengthIn35mmFormat", []Type{SHORT}, 1, "equivalent focal length assuming a 35mm film camera"},
+	{IFDExif, 0xA406, "SceneCaptureType", []Type{SHORT}, 1, "type of scene that was shot"},
+	{IFDExif, 0xA407, "GainControl", []Type{SHORT}, 1, "degree of overall image gain adjustment"},
+	{IFDExif, 0xA408, "Contrast", []Type{SHORT}, 1, "direction of contrast processing"},
+	{IFDExif, 0xA409, "Saturation", []Type{SHORT}, 1, "direction of saturation processing"},
+	{IFDExif, 0xA40A, "Sharpness", []Type{SHORT}, 1, "direction of sharpness processing"},
+	{IFDExif, 0xA40B, "DeviceSettingDescription", []Type{UNDEFINED}, 0, "picture-taking conditions of a particular camera model"},
+	{IFDExif, 0xA40C, "SubjectDistanceRange", []Type{SHORT}, 1, "distance to the subject"},
+	{IFDExif, 0xA420, "ImageUniqueID", []Type{ASCII}, 33, "unique identifier assigned to the image"},
+	{IFDExif, 0xA430, "OwnerName", []Type{ASCII}, 0, "owner of the camera"},
+	{IFDExif, 0xA431, "SerialNumber", []Type{ASCII}, 0, "serial number of the camera body"},
+	{IFDExif, 0xA432, "LensInfo", []Type{RATIONAL}, 4, "min/max focal length and min/max F number of the lens"},
+	{IFDExif, 0xA433, "LensMake", []Type{ASCII}, 0, "lens manufacturer"},
+	{IFDExif, 0xA434, "LensModel", []Type{ASCII}, 0, "lens model name and number"},
+	{IFDExif, 0xA435, "LensSerialNumber", []Type{ASCII}, 0, "lens serial number"},
+	{IFDExif, 0xA436, "ImageTitle", []Type{ASCII}, 0, "title of the image"},
+	{IFDExif, 0xA437, "Photographer", []Type{ASCII}, 0, "name of the photographer"},
+	{IFDExif, 0xA438, "ImageEditor", []Type{ASCII}, 0, "name of the main person who edited the image"},
+	{IFDExif, 0xA439, "CameraFirmware", []Type{ASCII}, 0, "firmware of the camera"},
+	{IFDExif, 0xA43A, "RAWDevelopingSoftware", []Type{ASCII}, 0, "software used to develop the raw image"},
+	{IFDExif, 0xA43B, "ImageEditingSoftware", []Type{ASCII}, 0, "software used to edit the image"},
+	{IFDExif, 0xA43C, "MetadataEditingSoftware", []Type{ASCII}, 0, "software used to edit the metadata"},
+	{IFDExif, 0xA460, "CompositeImage", []Type{SHORT}, 1, "whether the image is a composite image"},
+	{IFDExif, 0xA461, "CompositeImageCount", []Type{SHORT}, 2, "number of source images of a composite image"},
+	{IFDExif, 0xA462, "CompositeImageExposureTimes", []Type{UNDEFINED}, 0, "exposure times of the source images of a composite image"},
+	{IFDExif, 0xA500, "Gamma", []Type{RATIONAL}, 1, "gamma coefficient"},
+	{IFDExif, 0xEA1D, "OffsetSchema", []Type{SLONG}, 1, "Microsoft offset schema"},
+	{IFDGPS, 0x0000, "GPSVersionID", []Type{BYTE}, 4, "version of the GPS IFD"},
+	{IFDGPS, 0x0001, "GPSLatitudeRef", []Type{ASCII}, 2, "north or south latitude"},
+	{IFDGPS, 0x0002, "GPSLatitude", []Type{RATIONAL}, 3, "latitude as degrees and minutes and seconds"},
+	{IFDGPS, 0x0003, "GPSLongitudeRef", []Type{ASCII}, 2, "east or west longitude"},
+	{IFDGPS, 0x0004, "GPSLongitude", []Type{RATIONAL}, 3, "longitude as degrees and minutes and seconds"},
+	{IFDGPS, 0x0005, "GPSAltitudeRef", []Type{BYTE}, 1, "altitude above or below sea level"},
+	{IFDGPS, 0x0006, "GPSAltitude", []Type{RATIONAL}, 1, "altitude in meters"},
+	{IFDGPS, 0x0007, "GPSTimeStamp", []Type{RATIONAL}, 3, "UTC time as hours and minutes and seconds"},
+	{IFDGPS, 0x0008, "GPSSatellites", []Type{ASCII}, 0, "satellites used for measurements"},
+	{IFDGPS, 0x0009, "GPSStatus", []Type{ASCII}, 2, "status of the GPS receiver"},
+	{IFDGPS, 0x000A, "GPSMeasureMode", []Type{ASCII}, 2, "GPS measurement mode"},
+	{IFDGPS, 0x000B, "GPSDOP", []Type{RATIONAL}, 1, "data degree of precision"},
+	{IFDGPS, 0x000C, "GPSSpeedRef", []Type{ASCII}, 2, "unit of GPSSpeed"},
+	{IFDGPS, 0x000D, "GPSSpeed", []Type{RATIONAL}, 1, "speed of the GPS receiver"},
+	{IFDGPS, 0x000E, "GPSTrackRef", []Type{ASCII}, 2, "reference for GPSTrack"},
+	{IFDGPS, 0x000F, "GPSTrack", []Type{RATIONAL}, 1, "direction of movement of the GPS receiver"},
+	{IFDGPS, 0x0010, "GPSImgDirectionRef", []Type{ASCII}, 2, "reference for GPSImgDirection"},
+	{IFDGPS, 0x0011, "GPSImgDirection", []Type{RATIONAL}, 1, "direction of the image when it was captured"},
+	{IFDGPS, 0x0012, "GPSMapDatum", []Type{ASCII}, 0, "geodetic survey data used by the GPS receiver"},
+	{IFDGPS, 0x0013, "GPSDestLatitudeRef", []Type{ASCII}, 2, "north or south latitude of the destination point"},
+	{IFDGPS, 0x0014, "GPSDestLatitude", []Type{RATIONAL}, 3, "latitude of the destination point"},
+	{IFDGPS, 0x0015, "GPSDestLongitudeRef", []Type{ASCII}, 2, "east or west longitude of the destination point"},
+	{IFDGPS, 0x0016, "GPSDestLongitude", []Type{RATIONAL}, 3, "longitude of the destination point"},
+	{IFDGPS, 0x0017, "GPSDestBearingRef", []Type{ASCII}, 2, "reference for GPSDestBearing"},
+	{IFDGPS, 0x0018, "GPSDestBearing", []Type{RATIONAL}, 1, "bearing to the destination point"},
+	{IFDGPS, 0x0019, "GPSDestDistanceRef", []Type{ASCII}, 2, "unit of GPSDestDistance"},
+	{IFDGPS, 0x001A, "GPSDestDistance", []Type{RATIONAL}, 1, "distance to the destination point"},
+	{IFDGPS, 0x001B, "GPSProcessingMethod", []Type{UNDEFINED}, 0, "name of the method used for location finding"},
+	{IFDGPS, 0x001C, "GPSAreaInformation", []Type{UNDEFINED}, 0, "name of the GPS area"},
+	{IFDGPS, 0x001D, "GPSDateStamp", []Type{ASCII}, 11, "UTC date as YYYY:MM:DD"},
+	{IFDGPS, 0x001E, "GPSDifferential", []Type{SHORT}, 1, "whether differential correction is applied"},
+	{IFDGPS, 0x001F, "GPSHPositioningError", []Type{RATIONAL}, 1, "horizontal positioning error in meters"},
+	{IFDInterop, 0x0001, "InteropIndex", []Type{ASCII}, 4, "interoperability rule"},
+	{IFDInterop, 0x0002, "InteropVersion", []Type{UNDEFINED}, 4, "interoperability version"},
+	{IFDInterop, 0x1000, "RelatedImageFileFormat", []Type{ASCII}, 0, "file format of the related image"},
+	{IFDInterop, 0x1001, "RelatedImageWidth", []Type{SHORT, LONG}, 1, "width of the related image"},
+	{IFDInterop, 0x1002, "RelatedImageHeight", []Type{SHORT, LONG}, 1, "height of the related image"},
+}
@@ -0,0 +1,87 @@
+package track
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReadGPX reads the track points (trkpt) of a GPX 1.0 or 1.1 document,
+// the ones without time are skipped.
+func ReadGPX(r io.Reader) (Track, error) {
+	var (
+		points []Point
+		pt     *Point
+		text   strings.Builder
+	)
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read GPX")
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			text.Reset()
+			if tok.Name.Local != "trkpt" {
+				continue
+			}
+			pt = &Point{}
+			for _, a := range tok.Attr {
+				switch a.Name.Local {
+				case "lat":
+					pt.Latitude, err = strconv.ParseFloat(strings.TrimSpace(a.Value), 64)
+				case "lon":
+					pt.Longitude, err = strconv.ParseFloat(strings.TrimSpace(a.Value), 64)
+				}
+				if err != nil {
+					return nil, errors.Wrapf(err, "GPX line %d", lineOf(d))
+				}
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			if pt == nil {
+				continue
+			}
+			switch tok.Name.Local {
+			case "time":
+				t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(text.String()))
+				if err != nil {
+					return nil, errors.Wrapf(err, "GPX line %d", lineOf(d))
+				}
+				pt.Time = t
+			case "ele":
+				ele, err := strconv.ParseFloat(strings.TrimSpace(text.String()), 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "GPX line %d", lineOf(d))
+				}
+				pt.Elevation = &ele
+			case "trkpt":
+				if !pt.Time.IsZero() {
+					points = append(points, *pt)
+				}
+				pt = nil
+			}
+		}
+	}
+
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	return New(points), nil
+}
+
+// lineOf returns the current line of d, for error messages.
+func lineOf(d *xml.Decoder) int {
+	line, _ := d.InputPos()
+	return line
+}
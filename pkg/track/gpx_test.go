@@ -0,0 +1,26 @@
+package track
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadGPXErrors(t *testing.T) {
+	for _, input := range []string{
+		`<gpx><trk><trkseg><trkpt lat="north" lon="5.2"><time>2021-06-12T08:00:00Z</time></trkpt></trkseg></trk></gpx>`,
+		`<gpx><trk><trkseg><trkpt lat="45.7" lon="5.2"><time>08:00</time></trkpt></trkseg></trk></gpx>`,
+		`<gpx><trk><trkseg><trkpt lat="45.7" lon="5.2"><ele>high</ele></trkpt></trkseg></trk></gpx>`,
+		`<gpx><trk><trkseg><trkpt lat="45.7" lon="5.2">`,
+	} {
+		if got, err := ReadGPX(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadGPX(%q) = %v, want an error", input, got)
+		}
+	}
+
+	// waypoints and route points have no time in a track
+	input := `<gpx><wpt lat="45.7" lon="5.2"><time>2021-06-12T08:00:00Z</time></wpt></gpx>`
+	if _, err := ReadGPX(strings.NewReader(input)); !errors.Is(err, ErrNoPoints) {
+		t.Errorf("ReadGPX(%q): got %v, want ErrNoPoints", input, err)
+	}
+}
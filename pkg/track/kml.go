@@ -0,0 +1,130 @@
+package track
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReadKML reads the timed positions of a KML document: the when and
+// coord elements of gx:Track, and the Placemarks with a TimeStamp and
+// a Point. Other geometries have no time and are skipped.
+func ReadKML(r io.Reader) (Track, error) {
+	var (
+		points []Point
+		text   strings.Builder
+
+		// gx:Track being read
+		inTrack bool
+		whens   []time.Time
+		coords  []Point
+
+		// Placemark being read, with its TimeStamp and Point
+		inPlacemark, inPoint bool
+		when                 time.Time
+		coord                *Point
+	)
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read KML")
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			text.Reset()
+			switch tok.Name.Local {
+			case "Track":
+				inTrack, whens, coords = true, nil, nil
+			case "Placemark":
+				inPlacemark, when, coord = true, time.Time{}, nil
+			case "Point":
+				inPoint = true
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			switch tok.Name.Local {
+			case "when":
+				t, err := time.Parse(time.RFC3339Nano, s)
+				if err != nil {
+					return nil, errors.Wrapf(err, "KML line %d", lineOf(d))
+				}
+				if inTrack {
+					whens = append(whens, t)
+				} else if inPlacemark {
+					when = t
+				}
+			case "coord":
+				if !inTrack {
+					continue
+				}
+				p, err := parseCoord(strings.Fields(s))
+				if err != nil {
+					return nil, errors.Wrapf(err, "KML line %d", lineOf(d))
+				}
+				coords = append(coords, p)
+			case "coordinates":
+				if !inPoint {
+					continue
+				}
+				p, err := parseCoord(strings.Split(s, ","))
+				if err != nil {
+					return nil, errors.Wrapf(err, "KML line %d", lineOf(d))
+				}
+				coord = &p
+			case "Point":
+				inPoint = false
+			case "Track":
+				if len(whens) != len(coords) {
+					return nil, errors.Errorf("KML line %d: track has %d when and %d coord", lineOf(d), len(whens), len(coords))
+				}
+				for i, p := range coords {
+					p.Time = whens[i]
+					points = append(points, p)
+				}
+				inTrack = false
+			case "Placemark":
+				if coord != nil && !when.IsZero() {
+					coord.Time = when
+					points = append(points, *coord)
+				}
+				inPlacemark = false
+			}
+		}
+	}
+
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	return New(points), nil
+}
+
+// parseCoord parses the longitude, latitude and optional altitude
+// of a KML coordinate.
+func parseCoord(fields []string) (Point, error) {
+	if len(fields) < 2 || len(fields) > 3 {
+		return Point{}, errors.Errorf("bad coordinate %q", strings.Join(fields, ","))
+	}
+	v := make([]float64, len(fields))
+	for i, f := range fields {
+		var err error
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(f), 64); err != nil {
+			return Point{}, errors.Errorf("bad coordinate %q", strings.Join(fields, ","))
+		}
+	}
+	p := Point{Longitude: v[0], Latitude: v[1]}
+	if len(v) == 3 {
+		p.Elevation = &v[2]
+	}
+	return p, nil
+}
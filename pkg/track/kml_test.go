@@ -0,0 +1,21 @@
+package track
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadKMLErrors(t *testing.T) {
+	for _, input := range []string{
+		// when and coord don't pair up
+		`<kml><gx:Track><when>2021-06-12T08:00:00Z</when></gx:Track></kml>`,
+		`<kml><gx:Track><when>2021-06-12T08:00:00Z</when><gx:coord>5.2 45.7 1 2</gx:coord></gx:Track></kml>`,
+		`<kml><Placemark><TimeStamp><when>12/06/2021</when></TimeStamp></Placemark></kml>`,
+		`<kml><Placemark><Point><coordinates>5.2</coordinates></Point></Placemark></kml>`,
+		`<kml><Placemark>`,
+	} {
+		if got, err := ReadKML(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadKML(%q) = %v, want an error", input, got)
+		}
+	}
+}
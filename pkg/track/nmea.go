@@ -0,0 +1,161 @@
+package track
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReadNMEA reads the RMC and GGA sentences of an NMEA 0183 log, from any
+// talker (GP, GN, GL...). RMC sentences give the date, time and position,
+// GGA sentences the altitude of the position at the same time; GGA sentences
+// before the first RMC have no date and are skipped, as are sentences with
+// a bad checksum or without fix. GGA sentences whose time of day wraps past
+// midnight are dated the day after the last RMC.
+func ReadNMEA(r io.Reader) (Track, error) {
+	var (
+		points []Point
+		index  = map[time.Time]int{} // points by time, to merge RMC and GGA
+		date   time.Time             // of the last RMC, or the day after
+		last   time.Time             // time of the last sentence
+	)
+	add := func(p Point) {
+		if i, ok := index[p.Time]; ok {
+			if p.Elevation != nil {
+				points[i].Elevation = p.Elevation
+			}
+			return
+		}
+		index[p.Time] = len(points)
+		points = append(points, p)
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields, ok := nmeaFields(sc.Text())
+		if !ok || len(fields[0]) != 5 {
+			continue
+		}
+
+		switch fields[0][2:] {
+		case "RMC":
+			// time, status, lat, N/S, lon, E/W, speed, track, date
+			if len(fields) < 10 || fields[2] != "A" {
+				continue
+			}
+			d, err := time.Parse("020106", fields[9])
+			if err != nil {
+				continue
+			}
+			date = d
+			t, ok1 := nmeaTime(date, fields[1])
+			if ok1 {
+				last = t
+			}
+			lat, ok2 := nmeaDegrees(fields[3], fields[4], "S")
+			lon, ok3 := nmeaDegrees(fields[5], fields[6], "W")
+			if ok1 && ok2 && ok3 {
+				add(Point{Time: t, Latitude: lat, Longitude: lon})
+			}
+		case "GGA":
+			// time, lat, N/S, lon, E/W, quality, satellites, HDOP, altitude, M
+			if len(fields) < 10 || date.IsZero() || fields[6] == "" || fields[6] == "0" {
+				continue
+			}
+			t, ok1 := nmeaTime(date, fields[1])
+			if ok1 {
+				if last.Sub(t) > 12*time.Hour {
+					// past midnight, before the RMC of the new day
+					date = date.AddDate(0, 0, 1)
+					t = t.AddDate(0, 0, 1)
+				}
+				last = t
+			}
+			lat, ok2 := nmeaDegrees(fields[2], fields[3], "S")
+			lon, ok3 := nmeaDegrees(fields[4], fields[5], "W")
+			if !ok1 || !ok2 || !ok3 {
+				continue
+			}
+			p := Point{Time: t, Latitude: lat, Longitude: lon}
+			if alt, err := strconv.ParseFloat(fields[9], 64); err == nil {
+				p.Elevation = &alt
+			}
+			add(p)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read NMEA")
+	}
+
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	return New(points), nil
+}
+
+// nmeaFields returns the comma-separated fields of a sentence, the first one
+// being its talker and type ("GPRMC"), false when it isn't a sentence or its
+// checksum doesn't match.
+func nmeaFields(line string) ([]string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "$") {
+		return nil, false
+	}
+	line = line[1:]
+	if i := strings.IndexByte(line, '*'); i >= 0 {
+		want, err := strconv.ParseUint(line[i+1:], 16, 8)
+		if err != nil {
+			return nil, false
+		}
+		var sum byte
+		for j := 0; j < i; j++ {
+			sum ^= line[j]
+		}
+		if sum != byte(want) {
+			return nil, false
+		}
+		line = line[:i]
+	}
+	return strings.Split(line, ","), true
+}
+
+// nmeaTime returns the time hhmmss.ss of date, in UTC.
+func nmeaTime(date time.Time, hms string) (time.Time, bool) {
+	if len(hms) < 6 {
+		return time.Time{}, false
+	}
+	h, err1 := strconv.Atoi(hms[0:2])
+	m, err2 := strconv.Atoi(hms[2:4])
+	s, err3 := strconv.ParseFloat(hms[4:], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return time.Time{}, false
+	}
+	sec := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s*float64(time.Second))
+	return date.Add(sec.Round(time.Millisecond)), true
+}
+
+// nmeaDegrees returns the (d)ddmm.mmmm value as decimal degrees,
+// negative when hemi is neg.
+func nmeaDegrees(v, hemi, neg string) (float64, bool) {
+	i := strings.IndexByte(v, '.')
+	if i < 0 {
+		i = len(v)
+	}
+	if i < 3 {
+		return 0, false
+	}
+	deg, err1 := strconv.Atoi(v[:i-2])
+	minutes, err2 := strconv.ParseFloat(v[i-2:], 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	d := float64(deg) + minutes/60
+	if hemi == neg {
+		d = -d
+	}
+	return d, true
+}
@@ -0,0 +1,110 @@
+package track
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nmeaFixture are the points of testdata/track.nmea: the GGA sentence
+// before the first RMC, the one with a checksum mismatch and the ones
+// without fix are skipped.
+var nmeaFixture = Track{
+	{Time: time.Date(2021, 6, 12, 8, 0, 0, 0, time.UTC), Latitude: 45.725, Longitude: 5.249, Elevation: ptr(210.5)},
+	{Time: time.Date(2021, 6, 12, 8, 1, 0, 0, time.UTC), Latitude: 45.726, Longitude: 5.251, Elevation: ptr(214.5)},
+	{Time: time.Date(2021, 12, 31, 23, 59, 59, 500e6, time.UTC), Latitude: -33.85, Longitude: -151.2},
+}
+
+func TestReadNMEA(t *testing.T) {
+	// RMC sentences have no altitude
+	rmc := Point{Time: nmeaFixture[0].Time, Latitude: 45.725, Longitude: 5.249}
+	tests := []struct {
+		name  string
+		input string
+		want  Track
+	}{
+		{
+			name:  "checksum",
+			input: "$GPRMC,080000.00,A,4543.5000,N,00514.9400,E,0.5,54.7,120621,,,A*6D\n",
+			want:  Track{rmc},
+		},
+		{
+			name:  "lower case checksum",
+			input: "$GPRMC,080000.00,A,4543.5000,N,00514.9400,E,0.5,54.7,120621,,,A*6d\n",
+			want:  Track{rmc},
+		},
+		{
+			name:  "no checksum",
+			input: "$GPRMC,080000.00,A,4543.5000,N,00514.9400,E,0.5,54.7,120621,,,A\n",
+			want:  Track{rmc},
+		},
+		{
+			name: "checksum mismatch",
+			input: "$GPRMC,080000.00,A,4543.5000,N,00514.9400,E,0.5,54.7,120621,,,A*6E\n" +
+				"$GPRMC,080100.00,A,4543.5600,N,00515.0600,E,0.5,54.7,120621,,,A*XX\n",
+		},
+		{
+			name: "GGA before RMC",
+			input: "$GPGGA,080000.00,4543.5000,N,00514.9400,E,1,08,0.9,210.5,M,47.0,M,,*6E\n" +
+				"$GPRMC,080000.00,A,4543.5000,N,00514.9400,E,0.5,54.7,120621,,,A*6D\n",
+			want: Track{rmc},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadNMEA(strings.NewReader(tt.input))
+			if tt.want == nil {
+				if !errors.Is(err, ErrNoPoints) {
+					t.Errorf("got %v, %v, want ErrNoPoints", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTrack(t, got, tt.want)
+		})
+	}
+}
+
+func TestReadNMEAMidnight(t *testing.T) {
+	got, err := ReadFile("testdata/midnight.nmea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	checkTrack(t, got, Track{
+		{Time: day.Add(24*time.Hour - time.Second), Latitude: -33.85, Longitude: -151.2, Elevation: ptr(12.5)},
+		{Time: day.Add(24 * time.Hour), Latitude: -33.8501, Longitude: -151.2001, Elevation: ptr(13)},
+		{Time: day.Add(24*time.Hour + time.Second), Latitude: -33.8502, Longitude: -151.2002, Elevation: ptr(13.5)},
+		{Time: day.Add(24*time.Hour + 2*time.Second), Latitude: -33.8503, Longitude: -151.2003},
+	})
+}
+
+func TestNMEADegrees(t *testing.T) {
+	tests := []struct {
+		v, hemi string
+		want    float64
+		ok      bool
+	}{
+		{"4543.5000", "N", 45.725, true},
+		{"4543.5000", "S", -45.725, true},
+		{"00514.9400", "E", 5.249, true},
+		{"15112", "W", -151.2, true},
+		{"012", "E", 0.2, true},
+		{"12", "E", 0, false},
+		{"", "N", 0, false},
+		{"45x3.5", "N", 0, false},
+	}
+	for _, tt := range tests {
+		neg := "S"
+		if tt.hemi == "E" || tt.hemi == "W" {
+			neg = "W"
+		}
+		got, ok := nmeaDegrees(tt.v, tt.hemi, neg)
+		if ok != tt.ok || (ok && (got-tt.want > 1e-9 || tt.want-got > 1e-9)) {
+			t.Errorf("nmeaDegrees(%q, %q) = %v, %t, want %v, %t", tt.v, tt.hemi, got, ok, tt.want, tt.ok)
+		}
+	}
+}
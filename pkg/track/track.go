@@ -0,0 +1,146 @@
+// Package track reads GPS track logs (GPX, KML and NMEA 0183) into
+// time-ordered points and interpolates positions between them.
+package track
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoPoints is returned when a track log has no timed position.
+var ErrNoPoints = errors.New("no track points")
+
+// Point is a timed position of a track.
+type Point struct {
+	Time time.Time
+
+	// decimal degrees, negative south of the equator and west of the prime meridian
+	Latitude  float64
+	Longitude float64
+
+	// meters above sea level (nil when absent)
+	Elevation *float64
+}
+
+// Track is a list of points ordered by time.
+type Track []Point
+
+// New returns the points as a Track, sorted by time.
+func New(points []Point) Track {
+	t := Track(points)
+	sort.SliceStable(t, func(i, j int) bool { return t[i].Time.Before(t[j].Time) })
+	return t
+}
+
+// Merge returns the points of tracks as one Track.
+func Merge(tracks ...Track) Track {
+	var points []Point
+	for _, t := range tracks {
+		points = append(points, t...)
+	}
+	return New(points)
+}
+
+// ReadFile reads the track log at path, see Read.
+func ReadFile(path string) (Track, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := Read(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+	return t, nil
+}
+
+// Read reads a GPX, KML or NMEA 0183 track log, the format being guessed
+// from its content: XML documents are GPX or KML after their root element,
+// other ones NMEA sentences.
+func Read(r io.Reader) (Track, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(512)
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	if !bytes.HasPrefix(head, []byte("<")) {
+		return ReadNMEA(br)
+	}
+
+	// the root element follows the XML declaration and comments
+	kml, gpx := bytes.Index(head, []byte("<kml")), bytes.Index(head, []byte("<gpx"))
+	if kml >= 0 && (gpx < 0 || kml < gpx) {
+		return ReadKML(br)
+	}
+	return ReadGPX(br)
+}
+
+// At returns the position of the track at time t, linearly interpolated
+// between the points before and after it. Points farther apart than maxGap
+// aren't interpolated: t is then matched to the closest one, as before the
+// first point and after the last one, when it's within maxGap. A zero
+// maxGap interpolates any gap and never matches times outside of the track.
+func (tr Track) At(t time.Time, maxGap time.Duration) (Point, bool) {
+	if len(tr) == 0 {
+		return Point{}, false
+	}
+
+	// first point at or after t
+	i := sort.Search(len(tr), func(i int) bool { return !tr[i].Time.Before(t) })
+	switch {
+	case i < len(tr) && tr[i].Time.Equal(t):
+		return tr[i], true
+	case i == 0:
+		return closest(tr[0], t, maxGap)
+	case i == len(tr):
+		return closest(tr[len(tr)-1], t, maxGap)
+	}
+
+	p0, p1 := tr[i-1], tr[i]
+	gap := p1.Time.Sub(p0.Time)
+	if maxGap > 0 && gap > maxGap {
+		if t.Sub(p0.Time) < p1.Time.Sub(t) {
+			return closest(p0, t, maxGap)
+		}
+		return closest(p1, t, maxGap)
+	}
+
+	f := float64(t.Sub(p0.Time)) / float64(gap)
+	dLon := p1.Longitude - p0.Longitude
+	// across the antimeridian the short way round
+	if dLon > 180 {
+		dLon -= 360
+	} else if dLon < -180 {
+		dLon += 360
+	}
+	p := Point{
+		Time:      t,
+		Latitude:  p0.Latitude + f*(p1.Latitude-p0.Latitude),
+		Longitude: math.Remainder(p0.Longitude+f*dLon, 360),
+	}
+	if p0.Elevation != nil && p1.Elevation != nil {
+		ele := *p0.Elevation + f*(*p1.Elevation-*p0.Elevation)
+		p.Elevation = &ele
+	}
+	return p, true
+}
+
+// closest returns p, at time t, when it's within maxGap of t.
+func closest(p Point, t time.Time, maxGap time.Duration) (Point, bool) {
+	d := p.Time.Sub(t)
+	if d < 0 {
+		d = -d
+	}
+	if maxGap <= 0 || d > maxGap {
+		return Point{}, false
+	}
+	p.Time = t
+	return p, true
+}
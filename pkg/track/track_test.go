@@ -0,0 +1,156 @@
+package track
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ptr(f float64) *float64 { return &f }
+
+// fixture are the points of testdata/track.gpx and testdata/track.kml.
+var fixture = Track{
+	{Time: time.Date(2021, 6, 12, 7, 0, 0, 500e6, time.UTC), Latitude: 45.7300, Longitude: 5.2600},
+	{Time: time.Date(2021, 6, 12, 8, 0, 0, 0, time.UTC), Latitude: 45.7250, Longitude: 5.2490, Elevation: ptr(210.5)},
+	{Time: time.Date(2021, 6, 12, 8, 1, 0, 0, time.UTC), Latitude: 45.7260, Longitude: 5.2510, Elevation: ptr(214.5)},
+}
+
+// checkTrack compares the points of got and want, positions to about 1 cm.
+func checkTrack(t *testing.T, got, want Track) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%d points, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if !samePoint(p, want[i]) {
+			t.Errorf("point %d = %s, want %s", i, show(p), show(want[i]))
+		}
+	}
+}
+
+func samePoint(p, q Point) bool {
+	return p.Time.Equal(q.Time) &&
+		math.Abs(p.Latitude-q.Latitude) < 1e-7 && math.Abs(p.Longitude-q.Longitude) < 1e-7 &&
+		(p.Elevation == nil) == (q.Elevation == nil) &&
+		(p.Elevation == nil || math.Abs(*p.Elevation-*q.Elevation) < 1e-9)
+}
+
+// show formats p for test failures.
+func show(p Point) string {
+	s := fmt.Sprintf("%s %.7f,%.7f", p.Time.Format(time.RFC3339Nano), p.Latitude, p.Longitude)
+	if p.Elevation != nil {
+		s += fmt.Sprintf(" %gm", *p.Elevation)
+	}
+	return s
+}
+
+func TestRead(t *testing.T) {
+	for _, path := range []string{"testdata/track.gpx", "testdata/track.kml", "testdata/track.nmea"} {
+		t.Run(path, func(t *testing.T) {
+			got, err := ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := fixture
+			if strings.HasSuffix(path, ".nmea") {
+				want = nmeaFixture
+			}
+			checkTrack(t, got, want)
+		})
+	}
+
+	if _, err := Read(strings.NewReader("\xEF\xBB\xBF  <?xml version=\"1.0\"?>\n<!-- <kml> -->\n<gpx></gpx>")); !errors.Is(err, ErrNoPoints) {
+		t.Errorf("empty GPX: got %v, want ErrNoPoints", err)
+	}
+	if _, err := Read(strings.NewReader("")); !errors.Is(err, ErrNoPoints) {
+		t.Errorf("empty file: got %v, want ErrNoPoints", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	gpx, err := ReadFile("testdata/track.gpx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nmea, err := ReadFile("testdata/track.nmea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Merge(nmea, gpx)
+	if len(got) != len(gpx)+len(nmea) {
+		t.Fatalf("%d points, want %d", len(got), len(gpx)+len(nmea))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Time.Before(got[i-1].Time) {
+			t.Errorf("point %d at %v is before point %d at %v", i, got[i].Time, i-1, got[i-1].Time)
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	t0 := time.Date(2021, 6, 12, 8, 0, 0, 0, time.UTC)
+	tr := New([]Point{
+		{Time: t0.Add(10 * time.Minute), Latitude: 11, Longitude: 179, Elevation: ptr(100)},
+		{Time: t0, Latitude: 10, Longitude: 20, Elevation: ptr(100)},
+		{Time: t0.Add(time.Minute), Latitude: 10.5, Longitude: 21, Elevation: ptr(200)},
+		{Time: t0.Add(2 * time.Minute), Latitude: 11, Longitude: 22},
+		{Time: t0.Add(11 * time.Minute), Latitude: 12, Longitude: -179},
+	})
+	at := func(d time.Duration) time.Time { return t0.Add(d) }
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		maxGap time.Duration
+		want   *Point
+	}{
+		{"first point", at(0), time.Minute, &Point{Latitude: 10, Longitude: 20, Elevation: ptr(100)}},
+		{"last point", at(11 * time.Minute), time.Minute, &Point{Latitude: 12, Longitude: -179}},
+		{"midway", at(30 * time.Second), time.Minute, &Point{Latitude: 10.25, Longitude: 20.5, Elevation: ptr(150)}},
+		{"quarter", at(15 * time.Second), time.Minute, &Point{Latitude: 10.125, Longitude: 20.25, Elevation: ptr(125)}},
+		{"one elevation missing", at(90 * time.Second), time.Minute, &Point{Latitude: 10.75, Longitude: 21.5}},
+		{"antimeridian", at(10*time.Minute + 30*time.Second), time.Minute, &Point{Latitude: 11.5, Longitude: 180}},
+		{"antimeridian quarter", at(10*time.Minute + 45*time.Second), time.Minute, &Point{Latitude: 11.75, Longitude: -179.5}},
+
+		// the 8 minutes gap isn't interpolated
+		{"gap, closest before", at(5 * time.Minute), 4 * time.Minute, &Point{Latitude: 11, Longitude: 22}},
+		{"gap, closest after", at(7 * time.Minute), 4 * time.Minute, &Point{Latitude: 11, Longitude: 179, Elevation: ptr(100)}},
+		{"gap, too far", at(6 * time.Minute), 3 * time.Minute, nil},
+		{"gap interpolated", at(6 * time.Minute), 10 * time.Minute, &Point{Latitude: 11, Longitude: 100.5}},
+		{"zero maxGap interpolates", at(6 * time.Minute), 0, &Point{Latitude: 11, Longitude: 100.5}},
+
+		// outside of the track
+		{"before, within maxGap", at(-time.Minute), time.Minute, &Point{Latitude: 10, Longitude: 20, Elevation: ptr(100)}},
+		{"before, too far", at(-time.Minute - time.Second), time.Minute, nil},
+		{"after, within maxGap", at(12 * time.Minute), time.Minute, &Point{Latitude: 12, Longitude: -179}},
+		{"after, zero maxGap", at(11*time.Minute + time.Second), 0, nil},
+	}
+	for _, tt := range tests {
+		got, ok := tr.At(tt.t, tt.maxGap)
+		if tt.want == nil {
+			if ok {
+				t.Errorf("%s: got %s, want none", tt.name, show(got))
+			}
+			continue
+		}
+		want := *tt.want
+		want.Time = tt.t
+		if !ok || !samePoint(got, want) {
+			t.Errorf("%s: got %s, %t, want %s", tt.name, show(got), ok, show(want))
+		}
+	}
+
+	if _, ok := Track(nil).At(t0, time.Hour); ok {
+		t.Error("empty track matched")
+	}
+}
+
+func TestReadFileMissing(t *testing.T) {
+	if _, err := ReadFile("testdata/missing.gpx"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got %v, want os.ErrNotExist", err)
+	}
+}